@@ -0,0 +1,101 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"coreheadlines/dynamo"
+	"coreheadlines/telegram"
+	"coreheadlines/typesPkg"
+	"coreheadlines/words"
+)
+
+// NotifySubscribers DMs every subscriber the published articles matching
+// one of their tags. Failures for one chat don't stop the others.
+func (b *Bot) NotifySubscribers(ctx context.Context, articles []typesPkg.MainStruct) error {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	subs, err := dynamo.ListSubscriptions(ctx, b.db)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, sub := range subs {
+		var matched []typesPkg.MainStruct
+		for _, art := range articles {
+			if MatchesAnyTag(sub.Tags, art) {
+				matched = append(matched, art)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		opts := telegram.SendOptions{BoostChannel: b.channel}
+		_, err := telegram.SendMessages(ctx, matched, b.token, strconv.FormatInt(sub.ChatID, 10), opts)
+		if errors.Is(err, telegram.ErrDeadlineNear) || ctx.Err() != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func MatchesAnyTag(tags []string, art typesPkg.MainStruct) bool {
+	for _, tag := range tags {
+		if MatchesTag(tag, art) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesTag reports whether a subscription tag applies to an article: the
+// tag names its source, one of its entities ("nvidia", or "$nvda" for the
+// ticker) or appears as a whole word/phrase in the title.
+func MatchesTag(tag string, art typesPkg.MainStruct) bool {
+	if tag == "" {
+		return false
+	}
+	if sameSource(art.Header, tag) {
+		return true
+	}
+	if hasEntity(art.Entities, tag) {
+		return true
+	}
+	return words.Contains(strings.ToLower(art.Title), tag)
+}
+
+// hasEntity matches a lowercased tag against entity IDs and names, or
+// against tickers when it is a cashtag.
+func hasEntity(entities []typesPkg.Entity, tag string) bool {
+	ticker, isCashtag := strings.CutPrefix(tag, "$")
+	for _, e := range entities {
+		if isCashtag {
+			if e.Ticker != "" && strings.EqualFold(e.Ticker, ticker) {
+				return true
+			}
+			continue
+		}
+		if tag == e.ID || tag == strings.ToLower(e.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameSource compares feed headers loosely ("hackernews" == "Hacker News").
+func sameSource(header, s string) bool {
+	squash := func(v string) string {
+		return strings.ToLower(strings.ReplaceAll(v, " ", ""))
+	}
+	return header != "" && squash(header) == squash(s)
+}
@@ -0,0 +1,249 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"coreheadlines/dynamo"
+	"coreheadlines/feeds"
+	"coreheadlines/telegram"
+	"coreheadlines/typesPkg"
+	"coreheadlines/words"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+const (
+	resultLimit  = 10
+	searchWindow = 7 * 24 * time.Hour
+	maxTagLen    = 64
+	pollTimeout  = 50 * time.Second
+)
+
+const helpText = `<b>Core Headlines bot</b>
+/latest [source] – most recent posts, optionally from one source
+/sources – list the sources we follow
+/search &lt;term&gt; – search recent headlines
+/subscribe &lt;tag&gt; – get matching headlines in this chat
+/unsubscribe [tag] – stop one or all alerts`
+
+type Bot struct {
+	db       *dynamodb.Client
+	token    string
+	channel  string
+	username string // bot's @username without "@", for t.me/<bot>?start= links; optional
+}
+
+func New(db *dynamodb.Client, token, channel, username string) *Bot {
+	return &Bot{db: db, token: token, channel: channel, username: strings.TrimPrefix(username, "@")}
+}
+
+// HandleWebhook decodes one update as pushed by Telegram to the webhook.
+func (b *Bot) HandleWebhook(ctx context.Context, body []byte) error {
+	var u telegram.Update
+	if err := json.Unmarshal(body, &u); err != nil {
+		return fmt.Errorf("failed to decode update: %w", err)
+	}
+	return b.HandleUpdate(ctx, u)
+}
+
+// Poll long-polls getUpdates until ctx is done. Transient errors are handed
+// to onError and polling continues.
+func (b *Bot) Poll(ctx context.Context, onError func(error)) error {
+	var offset int64
+	for {
+		updates, err := telegram.GetUpdates(ctx, b.token, offset, pollTimeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			onError(err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(5 * time.Second):
+			}
+			continue
+		}
+
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			if err := b.HandleUpdate(ctx, u); err != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+func (b *Bot) HandleUpdate(ctx context.Context, u telegram.Update) error {
+	if u.CallbackQuery != nil {
+		return b.handleCallback(ctx, u.CallbackQuery)
+	}
+	if u.Message == nil || !strings.HasPrefix(u.Message.Text, "/") {
+		return nil
+	}
+
+	msg := u.Message
+	cmd, args := parseCommand(msg.Text)
+
+	switch cmd {
+	case "/start":
+		if strings.HasPrefix(args, boostStartPrefix) {
+			return b.boostStart(ctx, msg.Chat.ID, args)
+		}
+		return b.reply(ctx, msg.Chat.ID, helpText)
+	case "/help":
+		return b.reply(ctx, msg.Chat.ID, helpText)
+	case "/latest":
+		return b.latest(ctx, msg.Chat.ID, args)
+	case "/sources":
+		return b.sources(ctx, msg.Chat.ID)
+	case "/search":
+		return b.search(ctx, msg.Chat.ID, args)
+	case "/subscribe":
+		return b.subscribe(ctx, msg.Chat, args)
+	case "/unsubscribe":
+		return b.unsubscribe(ctx, msg.Chat, args)
+	default:
+		return b.reply(ctx, msg.Chat.ID, "Unknown command.\n\n"+helpText)
+	}
+}
+
+// parseCommand splits "/latest@coreheadlines_bot Hacker News" into
+// ("/latest", "Hacker News").
+func parseCommand(text string) (string, string) {
+	cmd, args, _ := strings.Cut(strings.TrimSpace(text), " ")
+	cmd, _, _ = strings.Cut(cmd, "@")
+	return strings.ToLower(cmd), strings.TrimSpace(args)
+}
+
+func (b *Bot) reply(ctx context.Context, chatID int64, text string) error {
+	return telegram.SendText(ctx, b.token, chatID, text)
+}
+
+func (b *Bot) latest(ctx context.Context, chatID int64, source string) error {
+	var posts []typesPkg.MainStruct
+	err := dynamo.ScanRecent(ctx, b.db, time.Now().Add(-searchWindow), func(rec dynamo.RecentArticleRecord) bool {
+		if source == "" || sameSource(rec.Header, source) {
+			posts = append(posts, rec.Article())
+		}
+		return len(posts) < resultLimit
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(posts) == 0 {
+		if source != "" {
+			return b.reply(ctx, chatID, "Nothing recent from <b>"+html.EscapeString(source)+"</b>. Try /sources.")
+		}
+		return b.reply(ctx, chatID, "Nothing published recently.")
+	}
+
+	title := "🗞 Latest"
+	if source != "" {
+		title += " from " + posts[0].Header
+	}
+	_, err = telegram.SendDigest(ctx, posts, b.token, strconv.FormatInt(chatID, 10), title)
+	return err
+}
+
+func (b *Bot) sources(ctx context.Context, chatID int64) error {
+	var sb strings.Builder
+	sb.WriteString("<b>Sources</b>")
+
+	seen := make(map[string]bool)
+	for _, fc := range feeds.Feeds {
+		if seen[fc.Header] {
+			continue
+		}
+		seen[fc.Header] = true
+		sb.WriteString("\n• " + html.EscapeString(fc.Header))
+	}
+
+	return b.reply(ctx, chatID, sb.String())
+}
+
+func (b *Bot) search(ctx context.Context, chatID int64, term string) error {
+	if term == "" {
+		return b.reply(ctx, chatID, "Usage: /search &lt;term&gt;")
+	}
+
+	var posts []typesPkg.MainStruct
+	err := dynamo.ScanRecent(ctx, b.db, time.Now().Add(-searchWindow), func(rec dynamo.RecentArticleRecord) bool {
+		if words.Contains(strings.ToLower(rec.Title), strings.ToLower(term)) {
+			posts = append(posts, rec.Article())
+		}
+		return len(posts) < resultLimit
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(posts) == 0 {
+		return b.reply(ctx, chatID, "No recent headlines match <b>"+html.EscapeString(term)+"</b>.")
+	}
+	_, err = telegram.SendDigest(ctx, posts, b.token, strconv.FormatInt(chatID, 10), "🔎 "+term)
+	return err
+}
+
+func (b *Bot) subscribe(ctx context.Context, chat telegram.Chat, args string) error {
+	if chat.Type != "private" {
+		return b.reply(ctx, chat.ID, "Alerts are sent by direct message – open a private chat with me and /subscribe there.")
+	}
+
+	tag := normalizeTag(args)
+	if tag == "" {
+		return b.reply(ctx, chat.ID, "Usage: /subscribe &lt;tag&gt; – a keyword or company (e.g. <i>nvidia</i>), a ticker (e.g. <i>$NVDA</i>) or a source (e.g. <i>Hacker News</i>)")
+	}
+	if len(tag) > maxTagLen {
+		return b.reply(ctx, chat.ID, "That tag is too long.")
+	}
+
+	if err := dynamo.AddSubscription(ctx, b.db, chat.ID, tag); err != nil {
+		return err
+	}
+	return b.reply(ctx, chat.ID, "✅ You'll get headlines matching <b>"+html.EscapeString(tag)+"</b>.")
+}
+
+func (b *Bot) unsubscribe(ctx context.Context, chat telegram.Chat, args string) error {
+	tag := normalizeTag(args)
+
+	if tag == "" {
+		if err := dynamo.RemoveSubscription(ctx, b.db, chat.ID, ""); err != nil {
+			return err
+		}
+		return b.reply(ctx, chat.ID, "🔕 All alerts removed.")
+	}
+
+	sub, err := dynamo.GetSubscription(ctx, b.db, chat.ID)
+	if err != nil {
+		return err
+	}
+	if !hasTag(sub.Tags, tag) {
+		return b.reply(ctx, chat.ID, "You're not subscribed to <b>"+html.EscapeString(tag)+"</b>.")
+	}
+
+	if err := dynamo.RemoveSubscription(ctx, b.db, chat.ID, tag); err != nil {
+		return err
+	}
+	return b.reply(ctx, chat.ID, "🔕 Removed <b>"+html.EscapeString(tag)+"</b>.")
+}
+
+func normalizeTag(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
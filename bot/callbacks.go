@@ -0,0 +1,62 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"html"
+	"strings"
+
+	"coreheadlines/dynamo"
+	"coreheadlines/telegram"
+)
+
+const boostStartPrefix = "boost_"
+
+// handleCallback answers inline button presses. The only callback we emit is
+// the Boost fallback ("boost:<BoostID>"). Buttons posted before it carried
+// the GUID itself, which is mapped to its ID.
+func (b *Bot) handleCallback(ctx context.Context, cq *telegram.CallbackQuery) error {
+	id, ok := strings.CutPrefix(cq.Data, telegram.BoostCallbackPrefix)
+	if !ok {
+		return telegram.AnswerCallback(ctx, b.token, cq.ID, "", false, "")
+	}
+	if !telegram.IsBoostID(id) {
+		id = telegram.BoostID(id)
+	}
+
+	// Count first, but always answer: a failed write must not leave the
+	// button spinning.
+	_, countErr := dynamo.IncrementBoostClicks(ctx, b.db, id)
+
+	var answerErr error
+	if channel := callbackChannel(cq); b.username != "" && channel != "" {
+		// answerCallbackQuery only accepts t.me/<bot>?start= links, so bounce
+		// through the bot's DM where the real boost link is sent.
+		link := "https://t.me/" + b.username + "?start=" + boostStartPrefix + channel
+		answerErr = telegram.AnswerCallback(ctx, b.token, cq.ID, "", false, link)
+	} else {
+		answerErr = telegram.AnswerCallback(ctx, b.token, cq.ID,
+			"⚡️ Thanks for the support! Open the channel info and tap Boost.", true, "")
+	}
+
+	return errors.Join(countErr, answerErr)
+}
+
+// callbackChannel is the public username of the channel the button was
+// pressed in, if it has one.
+func callbackChannel(cq *telegram.CallbackQuery) string {
+	if cq.Message == nil {
+		return ""
+	}
+	return cq.Message.Chat.Username
+}
+
+// boostStart replies to "/start boost_<channel>" with the boost deep link.
+func (b *Bot) boostStart(ctx context.Context, chatID int64, payload string) error {
+	channel := strings.TrimPrefix(payload, boostStartPrefix)
+	link := telegram.BoostURL("@" + channel)
+	if channel == "" || link == "" {
+		return b.reply(ctx, chatID, helpText)
+	}
+	return b.reply(ctx, chatID, `⚡️ <a href="`+html.EscapeString(link)+`">Boost @`+html.EscapeString(channel)+`</a> to unlock more features for the channel. Thank you!`)
+}
@@ -1,6 +1,6 @@
 #!/bin/bash
 
-# Call from root directory: ./build.sh [--local|--lambda]
+# Call from root directory: ./build.sh [--local|--poll|--lambda]
 
 ROOT_DIR=$(pwd)
 BUILD_DIR="./bin"
@@ -9,6 +9,8 @@ FUNCTION_NAME="coreheadlines"
 MODE="lambda" # default
 if [ "$1" = "--local" ]; then
     MODE="local"
+elif [ "$1" = "--poll" ]; then
+    MODE="poll"
 elif [ "$1" = "--lambda" ]; then
     MODE="lambda"
 fi
@@ -19,6 +21,12 @@ if [ "$MODE" = "local" ]; then
     exit $?
 fi
 
+if [ "$MODE" = "poll" ]; then
+    echo "🤖 Running bot with long polling..."
+    go run . -poll
+    exit $?
+fi
+
 echo "Cleaning build directory..."
 rm -rf "$BUILD_DIR"
 mkdir -p "$BUILD_DIR"
@@ -0,0 +1,289 @@
+// Command emojitool maintains the emoji, country and entity dictionaries
+// and their per-language additions.
+//
+//	emojitool validate [dir]   check dir's dictionary files (default: the embedded ones)
+//	emojitool explain [-dir dir] [-lang code] [-json] [title...]
+//	                           show which terms matched each title (or each line
+//	                           of stdin) and why their emojis were kept or dropped
+//	emojitool stem dir         collapse inflected terms in dir's dictionary files
+//	                           into base forms, rewriting them in place
+//	emojitool corpus [-dir dir] [-baseline file] [-save file] [file]
+//	                           score the emojis given for a golden corpus (default:
+//	                           tools/testdata/emoji_corpus.jsonl) by precision and
+//	                           recall; with -dir, compare dir's dictionaries against
+//	                           the embedded ones, with -baseline against the output
+//	                           an earlier run wrote with -save (e.g. before a code
+//	                           change)
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"coreheadlines/tools"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: emojitool validate [dir] | explain [-dir dir] [-lang code] [-json] [title...] | stem dir | corpus [-dir dir] [-baseline file] [-save file] [file]")
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	switch os.Args[1] {
+	case "validate":
+		os.Exit(validate(os.Args[2:]))
+	case "explain":
+		os.Exit(explain(os.Args[2:]))
+	case "stem":
+		os.Exit(stem(os.Args[2:]))
+	case "corpus":
+		os.Exit(corpus(os.Args[2:]))
+	default:
+		usage()
+	}
+}
+
+func validate(args []string) int {
+	dir := ""
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	d, problems, err := tools.ReadDictionaries(dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if d == nil {
+		return 1
+	}
+
+	codes := make([]string, 0, len(d.Translations))
+	for code := range d.Translations {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		t := d.Translations[code]
+		fmt.Printf("%s: %d country terms, %d emoji terms, %d stopwords\n", code, len(t.Countries), len(t.Emoji), len(t.Stopwords))
+	}
+	fmt.Printf("ok: %d country terms, %d emoji terms, %d entities, %d warnings\n", len(d.Countries), len(d.Emoji), len(d.Entities), len(problems))
+	return 0
+}
+
+func explain(args []string) int {
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	dir := fs.String("dir", "", "dictionary directory (default: the embedded dictionaries)")
+	language := fs.String("lang", "", "title language, ISO 639-1 (default: English)")
+	asJSON := fs.Bool("json", false, "print one JSON object per title")
+	fs.Parse(args)
+
+	if *dir != "" {
+		problems, err := tools.LoadDictionaries(*dir)
+		for _, p := range problems {
+			if p.Severity == tools.SeverityError {
+				fmt.Fprintln(os.Stderr, p)
+			}
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
+	titles := fs.Args()
+	if len(titles) == 0 {
+		sc := bufio.NewScanner(os.Stdin)
+		for sc.Scan() {
+			if t := strings.TrimSpace(sc.Text()); t != "" {
+				titles = append(titles, t)
+			}
+		}
+		if err := sc.Err(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetEscapeHTML(false)
+	for _, title := range titles {
+		e := tools.Explain(title, *language)
+		if *asJSON {
+			if err := enc.Encode(e); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return 1
+			}
+			continue
+		}
+		fmt.Println(e)
+	}
+	return 0
+}
+
+func stem(args []string) int {
+	if len(args) != 1 {
+		usage()
+	}
+
+	report, err := tools.CollapseInflections(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	for _, line := range report {
+		fmt.Println(line)
+	}
+	fmt.Printf("collapsed %d terms\n", len(report))
+	return validate(args)
+}
+
+const defaultCorpus = "tools/testdata/emoji_corpus.jsonl"
+
+// corpus scores the corpus and, given something to compare against, fails
+// when precision or recall drops, so an edit can be checked before it ships.
+func corpus(args []string) int {
+	fs := flag.NewFlagSet("corpus", flag.ExitOnError)
+	dir := fs.String("dir", "", "compare this dictionary directory against the embedded dictionaries")
+	baseline := fs.String("baseline", "", "compare against the output saved by an earlier -save")
+	save := fs.String("save", "", "write the output, in corpus format, to this file")
+	fs.Parse(args)
+
+	path := defaultCorpus
+	if fs.NArg() > 0 {
+		path = fs.Arg(0)
+	}
+	cases, err := readCorpusFile(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	// The reference run: the embedded dictionaries or a saved output
+	var before *tools.CorpusReport
+	beforeName := "embedded"
+	if *baseline != "" {
+		saved, err := readCorpusFile(*baseline)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		outputs, err := savedOutputs(cases, saved)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", *baseline, err)
+			return 1
+		}
+		r := tools.ScoreCorpus(cases, outputs)
+		before, beforeName = &r, *baseline
+	}
+	if *dir != "" {
+		if before == nil {
+			r := tools.RunCorpus(cases)
+			before = &r
+		}
+		problems, err := tools.LoadDictionaries(*dir)
+		for _, p := range problems {
+			if p.Severity == tools.SeverityError {
+				fmt.Fprintln(os.Stderr, p)
+			}
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+	after := tools.RunCorpus(cases)
+
+	if *save != "" {
+		out := make([]tools.CorpusCase, len(after.Results))
+		for i, r := range after.Results {
+			out[i] = tools.CorpusCase{Title: r.Case.Title, Lang: r.Case.Lang, Emojis: r.Got}
+		}
+		if err := writeCorpusFile(*save, out); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
+	if before == nil {
+		for _, r := range after.Results {
+			if !r.OK() {
+				fmt.Printf("%s\n  want %s\n  got  %s\n", r.Case.Title, strings.Join(r.Case.Emojis, ""), r.Summary())
+			}
+		}
+		fmt.Println(after)
+		return 0
+	}
+
+	for _, c := range tools.CompareCorpus(*before, after) {
+		fmt.Printf("%s\n  want   %s\n  before %s\n  after  %s\n",
+			c.After.Case.Title, strings.Join(c.After.Case.Emojis, ""), c.Before.Summary(), c.After.Summary())
+	}
+	afterName := "current"
+	if *dir != "" {
+		afterName = *dir
+	}
+	fmt.Printf("before (%s): %s\n", beforeName, before)
+	fmt.Printf("after (%s): %s\n", afterName, after)
+	dp, dr := after.Precision()-before.Precision(), after.Recall()-before.Recall()
+	fmt.Printf("precision %+.3f, recall %+.3f\n", dp, dr)
+	if dp < 0 || dr < 0 {
+		return 1
+	}
+	return 0
+}
+
+func readCorpusFile(path string) ([]tools.CorpusCase, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cases, err := tools.ReadCorpus(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cases, nil
+}
+
+func writeCorpusFile(path string, cases []tools.CorpusCase) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := tools.WriteCorpus(f, cases); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// savedOutputs lines a saved run up with cases by title and language.
+func savedOutputs(cases, saved []tools.CorpusCase) ([][]string, error) {
+	byTitle := make(map[[2]string][]string, len(saved))
+	for _, c := range saved {
+		byTitle[[2]string{c.Title, c.Lang}] = c.Emojis
+	}
+	outputs := make([][]string, len(cases))
+	for i, c := range cases {
+		got, ok := byTitle[[2]string{c.Title, c.Lang}]
+		if !ok {
+			return nil, fmt.Errorf("no saved output for %q, save a new baseline", c.Title)
+		}
+		outputs[i] = got
+	}
+	return outputs, nil
+}
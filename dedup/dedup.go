@@ -0,0 +1,104 @@
+package dedup
+
+import (
+	"net/url"
+	"strings"
+
+	"coreheadlines/typesPkg"
+)
+
+const (
+	maxHamming   = 3   // SimHash bits that may differ for a near-duplicate
+	minOverlap   = 0.8 // share of the shorter title's words found in the other
+	minOverlapSz = 4   // overlap only counts when both titles have this many words
+)
+
+type entry struct {
+	article typesPkg.MainStruct
+	link    string
+	words   map[string]bool
+	hash    uint64
+}
+
+// Detector remembers kept articles (recently published ones plus whatever was
+// accepted earlier in this run) and flags later near-duplicates.
+type Detector struct {
+	entries []entry
+	byLink  map[string]int
+}
+
+func NewDetector() *Detector {
+	return &Detector{byLink: make(map[string]int)}
+}
+
+func newEntry(art typesPkg.MainStruct) entry {
+	toks := tokens(art.Title)
+	words := make(map[string]bool, len(toks))
+	for _, t := range toks {
+		words[t] = true
+	}
+	return entry{
+		article: art,
+		link:    linkKey(art.Link),
+		words:   words,
+		hash:    simhash(shingles(toks)),
+	}
+}
+
+// Add records an article as kept and returns its index, in insertion order,
+// so callers can keep their own per-story state alongside.
+func (d *Detector) Add(art typesPkg.MainStruct) int {
+	e := newEntry(art)
+	idx := len(d.entries)
+	if e.link != "" {
+		if _, ok := d.byLink[e.link]; !ok {
+			d.byLink[e.link] = idx
+		}
+	}
+	d.entries = append(d.entries, e)
+	return idx
+}
+
+// Match returns the index of the kept article art duplicates and why
+// ("link", "simhash" or "overlap"); ok is false when art is new.
+func (d *Detector) Match(art typesPkg.MainStruct) (int, string, bool) {
+	e := newEntry(art)
+
+	if e.link != "" {
+		if i, ok := d.byLink[e.link]; ok {
+			return i, "link", true
+		}
+	}
+
+	for i, k := range d.entries {
+		if len(e.words) >= 2 && hamming(e.hash, k.hash) <= maxHamming {
+			return i, "simhash", true
+		}
+		if len(e.words) >= minOverlapSz && len(k.words) >= minOverlapSz && overlap(e.words, k.words) >= minOverlap {
+			return i, "overlap", true
+		}
+	}
+
+	return -1, "", false
+}
+
+// Article returns the kept article at idx.
+func (d *Detector) Article(idx int) typesPkg.MainStruct {
+	return d.entries[idx].article
+}
+
+// linkKey reduces a link to what identifies the page: host without "www.",
+// path without trailing slash, and the query; scheme and fragment dropped.
+func linkKey(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	key := host + strings.TrimSuffix(u.EscapedPath(), "/")
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+	return key
+}
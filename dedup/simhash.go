@@ -0,0 +1,103 @@
+package dedup
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+// Words that carry no story identity; dropping them keeps "Apple buys X" and
+// "Apple to buy X for $2B" close.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "or": true, "but": true,
+	"of": true, "to": true, "in": true, "on": true, "at": true, "for": true,
+	"by": true, "with": true, "from": true, "as": true, "into": true, "over": true,
+	"is": true, "are": true, "was": true, "were": true, "be": true, "been": true,
+	"it": true, "its": true, "this": true, "that": true, "these": true, "those": true,
+	"after": true, "says": true, "said": true, "report": true, "reports": true,
+	"new": true, "now": true, "will": true, "has": true, "have": true, "had": true,
+	"via": true, "amid": true, "about": true, "up": true, "out": true,
+}
+
+// tokens lowercases the title and returns its content words in order.
+func tokens(title string) []string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	out := words[:0]
+	for _, w := range words {
+		if stopwords[w] {
+			continue
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+// shingles are the word bigrams of the token stream (plus the lone token for
+// one-word titles).
+func shingles(toks []string) []string {
+	if len(toks) < 2 {
+		return toks
+	}
+	out := make([]string, 0, len(toks)-1)
+	for i := 0; i+1 < len(toks); i++ {
+		out = append(out, toks[i]+" "+toks[i+1])
+	}
+	return out
+}
+
+func hash64(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// simhash is Charikar's 64-bit SimHash over the given features.
+func simhash(features []string) uint64 {
+	var v [64]int
+	for _, f := range features {
+		h := hash64(f)
+		for i := range 64 {
+			if h&(1<<i) != 0 {
+				v[i]++
+			} else {
+				v[i]--
+			}
+		}
+	}
+
+	var out uint64
+	for i := range 64 {
+		if v[i] > 0 {
+			out |= 1 << i
+		}
+	}
+	return out
+}
+
+func hamming(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// overlap is |A∩B| / min(|A|,|B|): a short TLDR headline fully contained in
+// a longer Techmeme one scores 1.
+func overlap(a, b map[string]bool) float64 {
+	small, large := a, b
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	if len(small) == 0 {
+		return 0
+	}
+
+	common := 0
+	for t := range small {
+		if large[t] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(small))
+}
@@ -0,0 +1,45 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Boost button clicks are counted per article under "boost:<ID>", the ID
+// being telegram.BoostID of its GUID.
+const boostPrefix = "boost:"
+
+// IncrementBoostClicks bumps the click counter for an article's Boost ID
+// and returns the new total.
+func IncrementBoostClicks(ctx context.Context, db *dynamodb.Client, id string) (int64, error) {
+	result, err := db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(tableName),
+		Key: map[string]types.AttributeValue{
+			"guid":      &types.AttributeValueMemberS{Value: boostPrefix + id},
+			"timestamp": &types.AttributeValueMemberN{Value: "0"},
+		},
+		UpdateExpression: aws.String("ADD clicks :one"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":one": &types.AttributeValueMemberN{Value: "1"},
+		},
+		ReturnValues: types.ReturnValueUpdatedNew,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment boost clicks: %w", err)
+	}
+
+	n, ok := result.Attributes["clicks"].(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, nil
+	}
+	clicks, err := strconv.ParseInt(n.Value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse boost clicks: %w", err)
+	}
+	return clicks, nil
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
 
+const tableName = "coreheadlines_table"
+
 type PublishedArticleRecord struct {
 	GUID      string `dynamodbav:"guid"`      // Main table PK
 	Timestamp int64  `dynamodbav:"timestamp"` // Main table SK
@@ -21,7 +23,7 @@ type PublishedArticleRecord struct {
 
 func IsArticlePublished(ctx context.Context, db *dynamodb.Client, guid string) (bool, error) {
 	result, err := db.Query(ctx, &dynamodb.QueryInput{
-		TableName:              aws.String("coreheadlines_table"),
+		TableName:              aws.String(tableName),
 		KeyConditionExpression: aws.String("guid = :guid"),
 		ExpressionAttributeValues: map[string]types.AttributeValue{
 			":guid": &types.AttributeValueMemberS{Value: guid},
@@ -62,13 +64,17 @@ func BatchMarkPublished(
 		})
 	}
 
+	return batchWrite(ctx, db, writes)
+}
+
+func batchWrite(ctx context.Context, db *dynamodb.Client, writes []types.WriteRequest) error {
 	// max 25 per batch
 	for i := 0; i < len(writes); i += 25 {
 		end := min(i+25, len(writes))
 		batch := writes[i:end]
 		input := &dynamodb.BatchWriteItemInput{
 			RequestItems: map[string][]types.WriteRequest{
-				"coreheadlines_table": batch,
+				tableName: batch,
 			},
 		}
 
@@ -78,10 +84,10 @@ func BatchMarkPublished(
 		}
 
 		// retry unprocessed items if any
-		if un := resp.UnprocessedItems["coreheadlines_table"]; len(un) > 0 {
+		if un := resp.UnprocessedItems[tableName]; len(un) > 0 {
 			retryInput := &dynamodb.BatchWriteItemInput{
 				RequestItems: map[string][]types.WriteRequest{
-					"coreheadlines_table": un,
+					tableName: un,
 				},
 			}
 			if _, err := db.BatchWriteItem(ctx, retryInput); err != nil {
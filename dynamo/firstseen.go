@@ -0,0 +1,57 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Undated feed items get their age from when we first saw them, kept under
+// "firstseen:<GUID>". The TTL is refreshed on every sighting, so the record
+// lives as long as the item keeps showing up in its feed.
+const (
+	firstSeenPrefix    = "firstseen:"
+	firstSeenRetention = 30 * 24 * time.Hour
+)
+
+type FirstSeenRecord struct {
+	GUID      string `dynamodbav:"guid"`      // "firstseen:<GUID>"
+	Timestamp int64  `dynamodbav:"timestamp"` // always 0
+	TTL       int64  `dynamodbav:"ttl"`
+	Seen      int64  `dynamodbav:"seen"` // unix seconds
+}
+
+// FirstSeen records now as the first sighting of guid unless one exists,
+// and returns the first sighting either way.
+func FirstSeen(ctx context.Context, db *dynamodb.Client, guid string, now time.Time) (time.Time, error) {
+	result, err := db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(tableName),
+		Key: map[string]types.AttributeValue{
+			"guid":      &types.AttributeValueMemberS{Value: firstSeenPrefix + guid},
+			"timestamp": &types.AttributeValueMemberN{Value: "0"},
+		},
+		UpdateExpression: aws.String("SET seen = if_not_exists(seen, :now), #ttl = :ttl"),
+		ExpressionAttributeNames: map[string]string{
+			"#ttl": "ttl", // reserved word
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now": &types.AttributeValueMemberN{Value: fmt.Sprint(now.Unix())},
+			":ttl": &types.AttributeValueMemberN{Value: fmt.Sprint(now.Add(firstSeenRetention).Unix())},
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to record first sighting: %w", err)
+	}
+
+	var rec FirstSeenRecord
+	if err := attributevalue.UnmarshalMap(result.Attributes, &rec); err != nil {
+		return time.Time{}, fmt.Errorf("unmarshal first sighting: %w", err)
+	}
+	return time.Unix(rec.Seen, 0), nil
+}
@@ -0,0 +1,74 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Resolved redirect chains are cached under "link:<original URL>".
+const (
+	linkPrefix    = "link:"
+	linkRetention = 30 * 24 * time.Hour
+)
+
+type ResolvedLinkRecord struct {
+	GUID      string `dynamodbav:"guid"`      // "link:<original URL>"
+	Timestamp int64  `dynamodbav:"timestamp"` // always 0
+	TTL       int64  `dynamodbav:"ttl"`
+	Target    string `dynamodbav:"target"`
+}
+
+func linkKey(link string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"guid":      &types.AttributeValueMemberS{Value: linkPrefix + link},
+		"timestamp": &types.AttributeValueMemberN{Value: "0"},
+	}
+}
+
+// GetResolvedLink returns the cached final URL for link, if any.
+func GetResolvedLink(ctx context.Context, db *dynamodb.Client, link string) (string, bool, error) {
+	result, err := db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(tableName),
+		Key:       linkKey(link),
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get resolved link: %w", err)
+	}
+	if result.Item == nil {
+		return "", false, nil
+	}
+
+	var rec ResolvedLinkRecord
+	if err := attributevalue.UnmarshalMap(result.Item, &rec); err != nil {
+		return "", false, fmt.Errorf("unmarshal resolved link: %w", err)
+	}
+	return rec.Target, rec.Target != "", nil
+}
+
+func PutResolvedLink(ctx context.Context, db *dynamodb.Client, link, target string) error {
+	rec := ResolvedLinkRecord{
+		GUID:      linkPrefix + link,
+		Timestamp: 0,
+		TTL:       time.Now().Add(linkRetention).Unix(),
+		Target:    target,
+	}
+	item, err := attributevalue.MarshalMap(rec)
+	if err != nil {
+		return fmt.Errorf("marshal resolved link: %w", err)
+	}
+
+	_, err = db.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(tableName),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put resolved link: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"coreheadlines/typesPkg"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Queues live in the main table under a synthetic partition key ("queue:<name>"),
+// one item per article, sorted by the enqueue time in nanoseconds.
+const queuePrefix = "queue:"
+
+type QueuedArticleRecord struct {
+	GUID        string `dynamodbav:"guid"`      // "queue:<name>"
+	Timestamp   int64  `dynamodbav:"timestamp"` // enqueue time (ns), keeps order
+	TTL         int64  `dynamodbav:"ttl"`
+	ArticleGUID string `dynamodbav:"article_guid"`
+	Title       string `dynamodbav:"title"`
+	Link        string `dynamodbav:"link"`
+	Header      string `dynamodbav:"header"`
+	Lang        string `dynamodbav:"lang,omitempty"`
+
+	Topics        []string            `dynamodbav:"topics,omitempty"` // classified at parse time
+	Entities      []typesPkg.Entity   `dynamodbav:"entities,omitempty"`
+	AlsoCoveredBy []typesPkg.Coverage `dynamodbav:"also_covered_by,omitempty"`
+}
+
+func EnqueueArticles(
+	ctx context.Context,
+	db *dynamodb.Client,
+	queue string,
+	articles []typesPkg.MainStruct,
+) error {
+	var writes []types.WriteRequest
+	now := time.Now()
+	ttl := now.AddDate(0, 0, 7).Unix()
+
+	for i, art := range articles {
+		rec := QueuedArticleRecord{
+			GUID:        queuePrefix + queue,
+			Timestamp:   now.UnixNano() + int64(i),
+			TTL:         ttl,
+			ArticleGUID: art.GUID,
+			Title:       art.Title,
+			Link:        art.Link,
+			Header:      art.Header,
+			Lang:        art.Lang,
+
+			Topics:        art.Topics,
+			Entities:      art.Entities,
+			AlsoCoveredBy: art.AlsoCoveredBy,
+		}
+		item, err := attributevalue.MarshalMap(rec)
+		if err != nil {
+			return fmt.Errorf("marshal queued record: %w", err)
+		}
+		writes = append(writes, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: item},
+		})
+	}
+
+	return batchWrite(ctx, db, writes)
+}
+
+// LoadQueue returns the queued records in enqueue order.
+func LoadQueue(ctx context.Context, db *dynamodb.Client, queue string) ([]QueuedArticleRecord, error) {
+	var records []QueuedArticleRecord
+
+	paginator := dynamodb.NewQueryPaginator(db, &dynamodb.QueryInput{
+		TableName:              aws.String(tableName),
+		KeyConditionExpression: aws.String("guid = :guid"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":guid": &types.AttributeValueMemberS{Value: queuePrefix + queue},
+		},
+		ScanIndexForward: aws.Bool(true),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query queue %q: %w", queue, err)
+		}
+		var batch []QueuedArticleRecord
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &batch); err != nil {
+			return nil, fmt.Errorf("unmarshal queue %q: %w", queue, err)
+		}
+		records = append(records, batch...)
+	}
+
+	return records, nil
+}
+
+func DeleteQueued(ctx context.Context, db *dynamodb.Client, records []QueuedArticleRecord) error {
+	var writes []types.WriteRequest
+	for _, rec := range records {
+		writes = append(writes, types.WriteRequest{
+			DeleteRequest: &types.DeleteRequest{Key: map[string]types.AttributeValue{
+				"guid":      &types.AttributeValueMemberS{Value: rec.GUID},
+				"timestamp": &types.AttributeValueMemberN{Value: fmt.Sprint(rec.Timestamp)},
+			}},
+		})
+	}
+
+	return batchWrite(ctx, db, writes)
+}
+
+func (r QueuedArticleRecord) Article() typesPkg.MainStruct {
+	return typesPkg.MainStruct{
+		GUID:          r.ArticleGUID,
+		Title:         r.Title,
+		Link:          r.Link,
+		Header:        r.Header,
+		Lang:          r.Lang,
+		Topics:        r.Topics,
+		Entities:      r.Entities,
+		AlsoCoveredBy: r.AlsoCoveredBy,
+	}
+}
@@ -0,0 +1,160 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"coreheadlines/typesPkg"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Everything posted to the channel is also kept for a while under a single
+// "recent" partition, newest last, so the bot can answer /latest and /search.
+const (
+	recentPartition = "recent"
+	recentRetention = 7 * 24 * time.Hour
+)
+
+type RecentArticleRecord struct {
+	GUID        string `dynamodbav:"guid"`      // "recent"
+	Timestamp   int64  `dynamodbav:"timestamp"` // publish time (ns)
+	TTL         int64  `dynamodbav:"ttl"`
+	ArticleGUID string `dynamodbav:"article_guid"`
+	Title       string `dynamodbav:"title"`
+	Link        string `dynamodbav:"link"`
+	Header      string `dynamodbav:"header"`
+	Lang        string `dynamodbav:"lang,omitempty"`
+	MessageID   int64  `dynamodbav:"message_id,omitempty"` // channel message, for later edits
+
+	CommentsLink string `dynamodbav:"comments_link,omitempty"` // keeps the comments button on edits
+
+	Topics        []string            `dynamodbav:"topics,omitempty"`   // keeps the hashtags on edits
+	Entities      []typesPkg.Entity   `dynamodbav:"entities,omitempty"` // and the cashtags
+	AlsoCoveredBy []typesPkg.Coverage `dynamodbav:"also_covered_by,omitempty"`
+}
+
+func RecordRecent(
+	ctx context.Context,
+	db *dynamodb.Client,
+	articles []typesPkg.MainStruct,
+) error {
+	var writes []types.WriteRequest
+	now := time.Now()
+	ttl := now.Add(recentRetention).Unix()
+
+	for i, art := range articles {
+		rec := RecentArticleRecord{
+			GUID:        recentPartition,
+			Timestamp:   now.UnixNano() + int64(i),
+			TTL:         ttl,
+			ArticleGUID: art.GUID,
+			Title:       art.Title,
+			Link:        art.Link,
+			Header:      art.Header,
+			Lang:        art.Lang,
+			MessageID:   art.MessageID,
+
+			CommentsLink:  art.CommentsLink,
+			Topics:        art.Topics,
+			Entities:      art.Entities,
+			AlsoCoveredBy: art.AlsoCoveredBy,
+		}
+		item, err := attributevalue.MarshalMap(rec)
+		if err != nil {
+			return fmt.Errorf("marshal recent record: %w", err)
+		}
+		writes = append(writes, types.WriteRequest{
+			PutRequest: &types.PutRequest{Item: item},
+		})
+	}
+
+	return batchWrite(ctx, db, writes)
+}
+
+// ScanRecent walks recently published articles newest first, calling fn for
+// each one until fn returns false or the window is exhausted.
+func ScanRecent(
+	ctx context.Context,
+	db *dynamodb.Client,
+	since time.Time,
+	fn func(RecentArticleRecord) bool,
+) error {
+	paginator := dynamodb.NewQueryPaginator(db, &dynamodb.QueryInput{
+		TableName:              aws.String(tableName),
+		KeyConditionExpression: aws.String("guid = :guid AND #ts >= :since"),
+		ExpressionAttributeNames: map[string]string{
+			"#ts": "timestamp",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":guid":  &types.AttributeValueMemberS{Value: recentPartition},
+			":since": &types.AttributeValueMemberN{Value: fmt.Sprint(since.UnixNano())},
+		},
+		ScanIndexForward: aws.Bool(false),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to query recent articles: %w", err)
+		}
+		var batch []RecentArticleRecord
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &batch); err != nil {
+			return fmt.Errorf("unmarshal recent articles: %w", err)
+		}
+		for _, rec := range batch {
+			if !fn(rec) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+// UpdateRecentCoverage replaces the "also covered by" list of a recent record.
+func UpdateRecentCoverage(
+	ctx context.Context,
+	db *dynamodb.Client,
+	rec RecentArticleRecord,
+	coverage []typesPkg.Coverage,
+) error {
+	av, err := attributevalue.Marshal(coverage)
+	if err != nil {
+		return fmt.Errorf("marshal coverage: %w", err)
+	}
+
+	_, err = db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(tableName),
+		Key: map[string]types.AttributeValue{
+			"guid":      &types.AttributeValueMemberS{Value: recentPartition},
+			"timestamp": &types.AttributeValueMemberN{Value: fmt.Sprint(rec.Timestamp)},
+		},
+		UpdateExpression: aws.String("SET also_covered_by = :cov"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":cov": av,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update coverage: %w", err)
+	}
+	return nil
+}
+
+func (r RecentArticleRecord) Article() typesPkg.MainStruct {
+	return typesPkg.MainStruct{
+		GUID:          r.ArticleGUID,
+		Title:         r.Title,
+		Link:          r.Link,
+		Header:        r.Header,
+		Lang:          r.Lang,
+		Topics:        r.Topics,
+		Entities:      r.Entities,
+		CommentsLink:  r.CommentsLink,
+		AlsoCoveredBy: r.AlsoCoveredBy,
+		MessageID:     r.MessageID,
+	}
+}
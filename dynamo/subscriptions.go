@@ -0,0 +1,111 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// All subscriptions share one partition; the sort key is the private chat ID
+// and the subscribed tags are kept as a string set on that item.
+const subscriptionsPartition = "subscriptions"
+
+type SubscriptionRecord struct {
+	GUID   string   `dynamodbav:"guid"`      // "subscriptions"
+	ChatID int64    `dynamodbav:"timestamp"` // private chat ID
+	Tags   []string `dynamodbav:"tags,stringset,omitempty"`
+}
+
+func subscriptionKey(chatID int64) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"guid":      &types.AttributeValueMemberS{Value: subscriptionsPartition},
+		"timestamp": &types.AttributeValueMemberN{Value: strconv.FormatInt(chatID, 10)},
+	}
+}
+
+func AddSubscription(ctx context.Context, db *dynamodb.Client, chatID int64, tag string) error {
+	_, err := db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:        aws.String(tableName),
+		Key:              subscriptionKey(chatID),
+		UpdateExpression: aws.String("ADD tags :tag"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":tag": &types.AttributeValueMemberSS{Value: []string{tag}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add subscription: %w", err)
+	}
+	return nil
+}
+
+// RemoveSubscription drops one tag, or every tag of the chat when tag is empty.
+func RemoveSubscription(ctx context.Context, db *dynamodb.Client, chatID int64, tag string) error {
+	var err error
+	if tag == "" {
+		_, err = db.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+			TableName: aws.String(tableName),
+			Key:       subscriptionKey(chatID),
+		})
+	} else {
+		_, err = db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+			TableName:        aws.String(tableName),
+			Key:              subscriptionKey(chatID),
+			UpdateExpression: aws.String("DELETE tags :tag"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":tag": &types.AttributeValueMemberSS{Value: []string{tag}},
+			},
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove subscription: %w", err)
+	}
+	return nil
+}
+
+func GetSubscription(ctx context.Context, db *dynamodb.Client, chatID int64) (SubscriptionRecord, error) {
+	var rec SubscriptionRecord
+	result, err := db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(tableName),
+		Key:       subscriptionKey(chatID),
+	})
+	if err != nil {
+		return rec, fmt.Errorf("failed to get subscription: %w", err)
+	}
+	if result.Item == nil {
+		return rec, nil
+	}
+	if err := attributevalue.UnmarshalMap(result.Item, &rec); err != nil {
+		return rec, fmt.Errorf("unmarshal subscription: %w", err)
+	}
+	return rec, nil
+}
+
+func ListSubscriptions(ctx context.Context, db *dynamodb.Client) ([]SubscriptionRecord, error) {
+	var records []SubscriptionRecord
+
+	paginator := dynamodb.NewQueryPaginator(db, &dynamodb.QueryInput{
+		TableName:              aws.String(tableName),
+		KeyConditionExpression: aws.String("guid = :guid"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":guid": &types.AttributeValueMemberS{Value: subscriptionsPartition},
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query subscriptions: %w", err)
+		}
+		var batch []SubscriptionRecord
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &batch); err != nil {
+			return nil, fmt.Errorf("unmarshal subscriptions: %w", err)
+		}
+		records = append(records, batch...)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,147 @@
+// Package expr is a small, statically typed expression language over article
+// fields, e.g.
+//
+//	header == "Hacker News" && score >= 200 && !title.contains("Show HN")
+//
+// Expressions are compiled once (type errors and unknown names are reported
+// with their line:column) and then evaluated per article.
+package expr
+
+import (
+	"fmt"
+	"strings"
+
+	"coreheadlines/typesPkg"
+)
+
+type Type int
+
+const (
+	String Type = iota
+	Number
+	Bool
+	List // list of strings
+)
+
+func (t Type) String() string {
+	switch t {
+	case String:
+		return "string"
+	case Number:
+		return "number"
+	case Bool:
+		return "bool"
+	case List:
+		return "list"
+	}
+	return "unknown"
+}
+
+type Value struct {
+	Type Type
+	Str  string
+	Num  float64
+	Bool bool
+	List []string
+}
+
+func strVal(s string) Value    { return Value{Type: String, Str: s} }
+func numVal(n float64) Value   { return Value{Type: Number, Num: n} }
+func boolVal(b bool) Value     { return Value{Type: Bool, Bool: b} }
+func listVal(l []string) Value { return Value{Type: List, List: l} }
+func (v Value) equal(o Value) bool {
+	switch v.Type {
+	case String:
+		return v.Str == o.Str
+	case Number:
+		return v.Num == o.Num
+	case Bool:
+		return v.Bool == o.Bool
+	}
+	return false
+}
+
+type evalFn func(*typesPkg.MainStruct) Value
+
+type Program struct {
+	src  string
+	typ  Type
+	eval evalFn
+}
+
+// Compile parses and type-checks src. Errors are *Error values.
+func Compile(src string) (*Program, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{src: src, toks: toks}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, errorf(src, t.pos, "unexpected %s after expression", describe(t))
+	}
+
+	return &Program{src: src, typ: n.typ, eval: n.eval}, nil
+}
+
+// CompileBool compiles an expression that must yield a bool (filters, routes).
+func CompileBool(src string) (*Program, error) {
+	return compileAs(src, Bool)
+}
+
+// CompileNumber compiles an expression that must yield a number.
+func CompileNumber(src string) (*Program, error) {
+	return compileAs(src, Number)
+}
+
+func compileAs(src string, want Type) (*Program, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, errorf(src, 0, "empty expression")
+	}
+	prog, err := Compile(src)
+	if err != nil {
+		return nil, err
+	}
+	if prog.typ != want {
+		return nil, errorf(src, 0, "expression is %s, want %s", prog.typ, want)
+	}
+	return prog, nil
+}
+
+func (p *Program) String() string { return p.src }
+func (p *Program) Type() Type     { return p.typ }
+
+func (p *Program) Eval(art typesPkg.MainStruct) Value {
+	return p.eval(&art)
+}
+
+// Bool evaluates a bool program; other types yield false.
+func (p *Program) Bool(art typesPkg.MainStruct) bool {
+	v := p.eval(&art)
+	return v.Type == Bool && v.Bool
+}
+
+// Number evaluates a number program; other types yield 0.
+func (p *Program) Number(art typesPkg.MainStruct) float64 {
+	v := p.eval(&art)
+	if v.Type != Number {
+		return 0
+	}
+	return v.Num
+}
+
+func describe(t token) string {
+	switch t.kind {
+	case tokIdent:
+		return fmt.Sprintf("identifier %q", t.text)
+	case tokString:
+		return fmt.Sprintf("string %q", t.text)
+	case tokNumber:
+		return "number " + t.text
+	}
+	return t.kind.String()
+}
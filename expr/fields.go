@@ -0,0 +1,74 @@
+package expr
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"coreheadlines/typesPkg"
+)
+
+type field struct {
+	typ Type
+	get func(*typesPkg.MainStruct) Value
+}
+
+// fields are the article attributes expressions can refer to.
+var fields = map[string]field{
+	"header":     {String, func(a *typesPkg.MainStruct) Value { return strVal(a.Header) }},
+	"title":      {String, func(a *typesPkg.MainStruct) Value { return strVal(a.Title) }},
+	"link":       {String, func(a *typesPkg.MainStruct) Value { return strVal(a.Link) }},
+	"guid":       {String, func(a *typesPkg.MainStruct) Value { return strVal(a.GUID) }},
+	"author":     {String, func(a *typesPkg.MainStruct) Value { return strVal(a.Author) }},
+	"domain":     {String, func(a *typesPkg.MainStruct) Value { return strVal(linkDomain(a.Link)) }},
+	"lang":       {String, func(a *typesPkg.MainStruct) Value { return strVal(a.Lang) }},
+	"categories": {List, func(a *typesPkg.MainStruct) Value { return listVal(a.Categories) }},
+	"topics":     {List, func(a *typesPkg.MainStruct) Value { return listVal(a.Topics) }},
+	"entities":   {List, func(a *typesPkg.MainStruct) Value { return listVal(entityNames(a.Entities, "")) }},
+	"people":     {List, func(a *typesPkg.MainStruct) Value { return listVal(entityNames(a.Entities, "person")) }},
+	"companies":  {List, func(a *typesPkg.MainStruct) Value { return listVal(entityNames(a.Entities, "company")) }},
+	"tickers":    {List, func(a *typesPkg.MainStruct) Value { return listVal(tickers(a.Entities)) }},
+	"score":      {Number, func(a *typesPkg.MainStruct) Value { return numVal(float64(a.Score)) }},
+	"comments":   {Number, func(a *typesPkg.MainStruct) Value { return numVal(float64(a.Comments)) }},
+}
+
+// Fields lists the available field names with their types, for docs and
+// error messages.
+func Fields() []string {
+	out := make([]string, 0, len(fields))
+	for name, f := range fields {
+		out = append(out, name+" ("+f.typ.String()+")")
+	}
+	sort.Strings(out)
+	return out
+}
+
+// linkDomain is the lowercased link host without a leading "www.".
+func linkDomain(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// entityNames lists the names of entities of the given type (any, if "").
+func entityNames(entities []typesPkg.Entity, typ string) []string {
+	var names []string
+	for _, e := range entities {
+		if typ == "" || e.Type == typ {
+			names = append(names, e.Name)
+		}
+	}
+	return names
+}
+
+func tickers(entities []typesPkg.Entity) []string {
+	var out []string
+	for _, e := range entities {
+		if e.Ticker != "" {
+			out = append(out, e.Ticker)
+		}
+	}
+	return out
+}
@@ -0,0 +1,205 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokLParen
+	tokRParen
+	tokLBrack
+	tokRBrack
+	tokComma
+	tokDot
+	tokNot
+	tokAnd
+	tokOr
+	tokEq
+	tokNe
+	tokLt
+	tokLe
+	tokGt
+	tokGe
+	tokMinus
+)
+
+var tokenNames = map[tokenKind]string{
+	tokEOF: "end of expression", tokIdent: "identifier", tokString: "string", tokNumber: "number",
+	tokLParen: "'('", tokRParen: "')'", tokLBrack: "'['", tokRBrack: "']'", tokComma: "','",
+	tokDot: "'.'", tokNot: "'!'", tokAnd: "'&&'", tokOr: "'||'", tokEq: "'=='", tokNe: "'!='",
+	tokLt: "'<'", tokLe: "'<='", tokGt: "'>'", tokGe: "'>='", tokMinus: "'-'",
+}
+
+func (k tokenKind) String() string { return tokenNames[k] }
+
+type token struct {
+	kind tokenKind
+	text string // identifier name, unquoted string or number literal
+	pos  int    // byte offset in the source
+}
+
+func lex(src string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+			continue
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(src) {
+				r, size := utf8.DecodeRuneInString(src[i:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				i += size
+			}
+			toks = append(toks, token{kind: tokIdent, text: src[start:i], pos: start})
+			continue
+		case r >= '0' && r <= '9':
+			start := i
+			for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.') {
+				i++
+			}
+			toks = append(toks, token{kind: tokNumber, text: src[start:i], pos: start})
+			continue
+		case r == '"':
+			s, n, err := lexString(src, i)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: tokString, text: s, pos: i})
+			i += n
+			continue
+		}
+
+		two := ""
+		if i+1 < len(src) {
+			two = src[i : i+2]
+		}
+		var kind tokenKind
+		n := 2
+		switch two {
+		case "&&":
+			kind = tokAnd
+		case "||":
+			kind = tokOr
+		case "==":
+			kind = tokEq
+		case "!=":
+			kind = tokNe
+		case "<=":
+			kind = tokLe
+		case ">=":
+			kind = tokGe
+		default:
+			n = 1
+			switch r {
+			case '(':
+				kind = tokLParen
+			case ')':
+				kind = tokRParen
+			case '[':
+				kind = tokLBrack
+			case ']':
+				kind = tokRBrack
+			case ',':
+				kind = tokComma
+			case '.':
+				kind = tokDot
+			case '!':
+				kind = tokNot
+			case '<':
+				kind = tokLt
+			case '>':
+				kind = tokGt
+			case '-':
+				kind = tokMinus
+			case '=':
+				return nil, errorf(src, i, "unexpected '=' (use '==' to compare)")
+			case '&', '|':
+				return nil, errorf(src, i, "unexpected %q (use '%c%c')", r, r, r)
+			default:
+				return nil, errorf(src, i, "unexpected character %q", r)
+			}
+		}
+		toks = append(toks, token{kind: kind, pos: i})
+		i += n
+	}
+
+	return append(toks, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+// lexString reads a double-quoted literal starting at src[start] and returns
+// its value and length in bytes.
+func lexString(src string, start int) (string, int, error) {
+	var b strings.Builder
+	for i := start + 1; i < len(src); i++ {
+		switch c := src[i]; c {
+		case '"':
+			return b.String(), i - start + 1, nil
+		case '\\':
+			if i+1 >= len(src) {
+				return "", 0, errorf(src, i, "unterminated escape")
+			}
+			i++
+			switch src[i] {
+			case '"', '\\':
+				b.WriteByte(src[i])
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				return "", 0, errorf(src, i-1, "unknown escape \\%c", src[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, errorf(src, start, "unterminated string")
+}
+
+// Error is a compile error pointing at a position in the expression.
+type Error struct {
+	Src    string
+	Offset int // byte offset
+	Line   int // 1-based
+	Col    int // 1-based, in runes
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Col, e.Msg)
+}
+
+// Caret renders the offending line with a marker under the error position.
+func (e *Error) Caret() string {
+	lines := strings.Split(e.Src, "\n")
+	if e.Line < 1 || e.Line > len(lines) {
+		return ""
+	}
+	return lines[e.Line-1] + "\n" + strings.Repeat(" ", e.Col-1) + "^"
+}
+
+func errorf(src string, offset int, format string, args ...any) *Error {
+	line, col := 1, 1
+	for _, r := range src[:min(offset, len(src))] {
+		if r == '\n' {
+			line, col = line+1, 1
+			continue
+		}
+		col++
+	}
+	return &Error{Src: src, Offset: offset, Line: line, Col: col, Msg: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,447 @@
+package expr
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"coreheadlines/typesPkg"
+)
+
+// node is a type-checked subexpression compiled to a closure. lit is set for
+// string literals so methods like matches() can compile their pattern once.
+type node struct {
+	typ  Type
+	pos  int
+	eval evalFn
+	lit  *string
+}
+
+type parser struct {
+	src  string
+	toks []token
+	i    int
+}
+
+func (p *parser) peek() token { return p.toks[p.i] }
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, errorf(p.src, t.pos, "expected %s, found %s", kind, describe(t))
+	}
+	return t, nil
+}
+
+func (p *parser) want(n node, typ Type, what string) error {
+	if n.typ != typ {
+		return errorf(p.src, n.pos, "%s needs %s, found %s", what, typ, n.typ)
+	}
+	return nil
+}
+
+// Precedence, lowest first: ||, &&, comparisons and `in`, unary ! and -,
+// postfix .method(...), primaries.
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return left, err
+	}
+	for p.peek().kind == tokOr {
+		op := p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return right, err
+		}
+		if err := p.want(left, Bool, "'||'"); err != nil {
+			return left, err
+		}
+		if err := p.want(right, Bool, "'||'"); err != nil {
+			return right, err
+		}
+		l, r := left.eval, right.eval
+		left = node{typ: Bool, pos: op.pos, eval: func(a *typesPkg.MainStruct) Value {
+			return boolVal(l(a).Bool || r(a).Bool)
+		}}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseCompare()
+	if err != nil {
+		return left, err
+	}
+	for p.peek().kind == tokAnd {
+		op := p.next()
+		right, err := p.parseCompare()
+		if err != nil {
+			return right, err
+		}
+		if err := p.want(left, Bool, "'&&'"); err != nil {
+			return left, err
+		}
+		if err := p.want(right, Bool, "'&&'"); err != nil {
+			return right, err
+		}
+		l, r := left.eval, right.eval
+		left = node{typ: Bool, pos: op.pos, eval: func(a *typesPkg.MainStruct) Value {
+			return boolVal(l(a).Bool && r(a).Bool)
+		}}
+	}
+	return left, nil
+}
+
+func (p *parser) parseCompare() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return left, err
+	}
+
+	op := p.peek()
+	isIn := op.kind == tokIdent && op.text == "in"
+	switch op.kind {
+	case tokEq, tokNe, tokLt, tokLe, tokGt, tokGe:
+	default:
+		if !isIn {
+			return left, nil
+		}
+	}
+	p.next()
+
+	right, err := p.parseUnary()
+	if err != nil {
+		return right, err
+	}
+	if next := p.peek(); next.kind >= tokEq && next.kind <= tokGe || next.kind == tokIdent && next.text == "in" {
+		return right, errorf(p.src, next.pos, "comparisons cannot be chained; use '&&'")
+	}
+
+	if isIn {
+		if err := p.want(left, String, "'in'"); err != nil {
+			return left, err
+		}
+		if err := p.want(right, List, "'in'"); err != nil {
+			return right, err
+		}
+		l, r := left.eval, right.eval
+		return node{typ: Bool, pos: op.pos, eval: func(a *typesPkg.MainStruct) Value {
+			return boolVal(listContains(r(a).List, l(a).Str))
+		}}, nil
+	}
+
+	if left.typ != right.typ {
+		return left, errorf(p.src, op.pos, "cannot compare %s %s %s", left.typ, op.kind, right.typ)
+	}
+	l, r := left.eval, right.eval
+
+	switch op.kind {
+	case tokEq, tokNe:
+		if left.typ == List {
+			return left, errorf(p.src, op.pos, "cannot compare lists with %s", op.kind)
+		}
+		neg := op.kind == tokNe
+		return node{typ: Bool, pos: op.pos, eval: func(a *typesPkg.MainStruct) Value {
+			return boolVal(l(a).equal(r(a)) != neg)
+		}}, nil
+	}
+
+	if left.typ != Number && left.typ != String {
+		return left, errorf(p.src, op.pos, "cannot order %s values with %s", left.typ, op.kind)
+	}
+	cmp := func(a *typesPkg.MainStruct) int {
+		lv, rv := l(a), r(a)
+		if lv.Type == Number {
+			switch {
+			case lv.Num < rv.Num:
+				return -1
+			case lv.Num > rv.Num:
+				return 1
+			}
+			return 0
+		}
+		return strings.Compare(lv.Str, rv.Str)
+	}
+	var test func(int) bool
+	switch op.kind {
+	case tokLt:
+		test = func(c int) bool { return c < 0 }
+	case tokLe:
+		test = func(c int) bool { return c <= 0 }
+	case tokGt:
+		test = func(c int) bool { return c > 0 }
+	default:
+		test = func(c int) bool { return c >= 0 }
+	}
+	return node{typ: Bool, pos: op.pos, eval: func(a *typesPkg.MainStruct) Value {
+		return boolVal(test(cmp(a)))
+	}}, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	switch t := p.peek(); t.kind {
+	case tokNot:
+		p.next()
+		n, err := p.parseUnary()
+		if err != nil {
+			return n, err
+		}
+		if err := p.want(n, Bool, "'!'"); err != nil {
+			return n, err
+		}
+		f := n.eval
+		return node{typ: Bool, pos: t.pos, eval: func(a *typesPkg.MainStruct) Value {
+			return boolVal(!f(a).Bool)
+		}}, nil
+	case tokMinus:
+		p.next()
+		n, err := p.parseUnary()
+		if err != nil {
+			return n, err
+		}
+		if err := p.want(n, Number, "'-'"); err != nil {
+			return n, err
+		}
+		f := n.eval
+		return node{typ: Number, pos: t.pos, eval: func(a *typesPkg.MainStruct) Value {
+			return numVal(-f(a).Num)
+		}}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return n, err
+	}
+	for p.peek().kind == tokDot {
+		p.next()
+		name, err := p.expect(tokIdent)
+		if err != nil {
+			return n, err
+		}
+		lparen, err := p.expect(tokLParen)
+		if err != nil {
+			return n, err
+		}
+		args, err := p.parseArgs(tokRParen)
+		if err != nil {
+			return n, err
+		}
+		if n, err = p.method(n, name, lparen.pos, args); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// parseArgs reads a comma-separated list up to and including the closing
+// token.
+func (p *parser) parseArgs(closing tokenKind) ([]node, error) {
+	var args []node
+	if p.peek().kind == closing {
+		p.next()
+		return args, nil
+	}
+	for {
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		t := p.next()
+		if t.kind == closing {
+			return args, nil
+		}
+		if t.kind != tokComma {
+			return nil, errorf(p.src, t.pos, "expected ',' or %s, found %s", closing, describe(t))
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokString:
+		s := t.text
+		return node{typ: String, pos: t.pos, lit: &s, eval: func(*typesPkg.MainStruct) Value {
+			return strVal(s)
+		}}, nil
+
+	case tokNumber:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return node{}, errorf(p.src, t.pos, "bad number %q", t.text)
+		}
+		return node{typ: Number, pos: t.pos, eval: func(*typesPkg.MainStruct) Value {
+			return numVal(f)
+		}}, nil
+
+	case tokIdent:
+		switch t.text {
+		case "true", "false":
+			b := t.text == "true"
+			return node{typ: Bool, pos: t.pos, eval: func(*typesPkg.MainStruct) Value {
+				return boolVal(b)
+			}}, nil
+		}
+		f, ok := fields[t.text]
+		if !ok {
+			return node{}, errorf(p.src, t.pos, "unknown field %q (have %s)", t.text, strings.Join(Fields(), ", "))
+		}
+		return node{typ: f.typ, pos: t.pos, eval: f.get}, nil
+
+	case tokLParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return n, err
+		}
+		if _, err := p.expect(tokRParen); err != nil {
+			return n, err
+		}
+		return n, nil
+
+	case tokLBrack:
+		items, err := p.parseArgs(tokRBrack)
+		if err != nil {
+			return node{}, err
+		}
+		fns := make([]evalFn, len(items))
+		for i, it := range items {
+			if err := p.want(it, String, "list element"); err != nil {
+				return it, err
+			}
+			fns[i] = it.eval
+		}
+		return node{typ: List, pos: t.pos, eval: func(a *typesPkg.MainStruct) Value {
+			out := make([]string, len(fns))
+			for i, f := range fns {
+				out[i] = f(a).Str
+			}
+			return listVal(out)
+		}}, nil
+	}
+
+	return node{}, errorf(p.src, t.pos, "unexpected %s", describe(t))
+}
+
+// method type-checks recv.name(args...) and compiles it.
+func (p *parser) method(recv node, name token, pos int, args []node) (node, error) {
+	arity := func(n int) error {
+		if len(args) != n {
+			return errorf(p.src, pos, "%s.%s takes %d argument(s), got %d", recv.typ, name.text, n, len(args))
+		}
+		for _, a := range args {
+			if err := p.want(a, String, name.text+" argument"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	f := recv.eval
+	res := node{pos: name.pos}
+
+	switch recv.typ {
+	case String:
+		switch name.text {
+		case "contains", "startsWith", "endsWith":
+			if err := arity(1); err != nil {
+				return res, err
+			}
+			test := map[string]func(s, sub string) bool{
+				"contains":   strings.Contains,
+				"startsWith": strings.HasPrefix,
+				"endsWith":   strings.HasSuffix,
+			}[name.text]
+			// Text matching is case-insensitive, like the keyword filters
+			arg := args[0].eval
+			res.typ, res.eval = Bool, func(a *typesPkg.MainStruct) Value {
+				return boolVal(test(strings.ToLower(f(a).Str), strings.ToLower(arg(a).Str)))
+			}
+			return res, nil
+
+		case "matches":
+			if err := arity(1); err != nil {
+				return res, err
+			}
+			if args[0].lit == nil {
+				return res, errorf(p.src, args[0].pos, "matches needs a string literal pattern")
+			}
+			re, err := regexp.Compile(*args[0].lit)
+			if err != nil {
+				return res, errorf(p.src, args[0].pos, "bad pattern: %v", err)
+			}
+			res.typ, res.eval = Bool, func(a *typesPkg.MainStruct) Value {
+				return boolVal(re.MatchString(f(a).Str))
+			}
+			return res, nil
+
+		case "lower", "upper":
+			if err := arity(0); err != nil {
+				return res, err
+			}
+			conv := strings.ToLower
+			if name.text == "upper" {
+				conv = strings.ToUpper
+			}
+			res.typ, res.eval = String, func(a *typesPkg.MainStruct) Value {
+				return strVal(conv(f(a).Str))
+			}
+			return res, nil
+
+		case "len":
+			if err := arity(0); err != nil {
+				return res, err
+			}
+			res.typ, res.eval = Number, func(a *typesPkg.MainStruct) Value {
+				return numVal(float64(len([]rune(f(a).Str))))
+			}
+			return res, nil
+		}
+
+	case List:
+		switch name.text {
+		case "contains":
+			if err := arity(1); err != nil {
+				return res, err
+			}
+			arg := args[0].eval
+			res.typ, res.eval = Bool, func(a *typesPkg.MainStruct) Value {
+				return boolVal(listContains(f(a).List, arg(a).Str))
+			}
+			return res, nil
+
+		case "len":
+			if err := arity(0); err != nil {
+				return res, err
+			}
+			res.typ, res.eval = Number, func(a *typesPkg.MainStruct) Value {
+				return numVal(float64(len(f(a).List)))
+			}
+			return res, nil
+		}
+	}
+
+	return res, errorf(p.src, name.pos, "%s has no method %q", recv.typ, name.text)
+}
+
+// listContains compares case-insensitively, since feed categories and tags
+// are inconsistently cased.
+func listContains(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimSpace(item), strings.TrimSpace(s)) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,24 +1,98 @@
 package feeds
 
+import (
+	"fmt"
+	"time"
+
+	"coreheadlines/filters"
+)
+
 type FeedConfig struct {
-	URL             string
-	Header          string
-	Agent           string // // "bot", "chrome", "reader"
-	EnhancedHeaders bool   // When true, use enhanced headers for the request
+	URL              string
+	Header           string
+	Agent            string        // // "bot", "chrome", "reader"
+	EnhancedHeaders  bool          // When true, use enhanced headers for the request
+	ResolveCanonical bool          // When true, fetch each new article and use its rel=canonical link
+	ResolveLinks     bool          // When true, follow redirect wrappers/shorteners to the final article URL
+	Language         string        // ISO 639-1 code of the feed ("es", "pt"); empty means "en"
+	MaxPerRun        int           // Post at most this many new items per run (0 = no limit); the rest wait
+	MaxAge           time.Duration // Skip items published longer ago than this (0 = no limit); undated items age from first sighting
+
+	Filters []filters.Rule // Per-feed include/exclude rules, applied on top of GlobalFilters
+
+	HN *HNOptions // hnrss.org feeds only, e.g. &HNOptions{MinPoints: 100}; nil keeps every story
+}
+
+// HNOptions set score thresholds for hnrss.org feeds. They are passed to
+// hnrss as query parameters and checked again on the parsed counts, since
+// points keep changing; held-back stories get another chance next run.
+type HNOptions struct {
+	MinPoints   int
+	MinComments int
+}
+
+// Rules are the thresholds as exclude filters, so drops are logged like any
+// other filter decision. A story always has at least one point, so a score
+// of 0 means the description could not be parsed: such items are kept
+// rather than dropped for counts nobody read.
+func (o *HNOptions) Rules() []filters.Rule {
+	if o == nil {
+		return nil
+	}
+	var rules []filters.Rule
+	if o.MinPoints > 0 {
+		rules = append(rules, filters.Rule{Action: filters.Exclude, Match: filters.Expr, Value: fmt.Sprintf("score > 0 && score < %d", o.MinPoints)})
+	}
+	if o.MinComments > 0 {
+		rules = append(rules, filters.Rule{Action: filters.Exclude, Match: filters.Expr, Value: fmt.Sprintf("score > 0 && comments < %d", o.MinComments)})
+	}
+	return rules
+}
+
+// GlobalFilters apply to every feed, e.g.
+// {Action: filters.Exclude, Match: filters.Domain, Value: "example.com"}.
+var GlobalFilters = []filters.Rule{}
+
+// Route also sends posts matching When (an expr expression) to Chat, e.g.
+// {Name: "security", Chat: "@coreheadlines_security", When: `"security" in topics`}.
+type Route struct {
+	Name string
+	Chat string
+	When string
+}
+
+var Routes = []Route{}
+
+// PriorityRule moves matching posts up (or down, when negative) within a run.
+// Priorities of all matching rules add up; ties keep feed order.
+type PriorityRule struct {
+	When     string
+	Priority int
 }
 
+var PriorityRules = []PriorityRule{}
+
 var Feeds = []FeedConfig{
-	// {
-	// 	URL:             "https://foro.elhacker.net/.xml.html;sa=news;board=34;limit=10;type=rss",
-	// 	Header:          "elhacker",
-	// 	Agent:           "bot",
-	// 	EnhancedHeaders: false,
-	// },
+	{
+		URL:             "https://foro.elhacker.net/.xml.html;sa=news;board=34;limit=10;type=rss",
+		Header:          "elhacker",
+		Agent:           "bot",
+		EnhancedHeaders: false,
+		Language:        "es",
+	},
+	{
+		URL:             "https://tecnoblog.net/feed/",
+		Header:          "Tecnoblog",
+		Agent:           "bot",
+		EnhancedHeaders: false,
+		Language:        "pt",
+	},
 	// {
 	// 	URL:             "https://feeds.feedburner.com/TheHackersNews",
 	// 	Header:          "The Hacker News",
 	// 	Agent:           "bot",
 	// 	EnhancedHeaders: false,
+	// 	ResolveLinks:    true,
 	// },
 	{
 		URL:             "https://techmeme.com/feed.xml",
@@ -43,36 +117,54 @@ var Feeds = []FeedConfig{
 		Header:          "TLDR",
 		Agent:           "bot",
 		EnhancedHeaders: false,
+		ResolveLinks:    true,
+		MaxPerRun:       3,
+		MaxAge:          48 * time.Hour,
 	},
 	{
 		URL:             "https://tldr.tech/api/rss/ai",
 		Header:          "TLDR",
 		Agent:           "bot",
 		EnhancedHeaders: false,
+		ResolveLinks:    true,
+		MaxPerRun:       3,
+		MaxAge:          48 * time.Hour,
 	},
 	{
 		URL:             "https://tldr.tech/api/rss/founders",
 		Header:          "TLDR",
 		Agent:           "bot",
 		EnhancedHeaders: false,
+		ResolveLinks:    true,
+		MaxPerRun:       3,
+		MaxAge:          48 * time.Hour,
 	},
 	{
 		URL:             "https://tldr.tech/api/rss/webdev",
 		Header:          "TLDR",
 		Agent:           "bot",
 		EnhancedHeaders: false,
+		ResolveLinks:    true,
+		MaxPerRun:       3,
+		MaxAge:          48 * time.Hour,
 	},
 	{
 		URL:             "https://tldr.tech/api/rss/infosec",
 		Header:          "TLDR",
 		Agent:           "bot",
 		EnhancedHeaders: false,
+		ResolveLinks:    true,
+		MaxPerRun:       3,
+		MaxAge:          48 * time.Hour,
 	},
 	{
 		URL:             "https://tldr.tech/api/rss/marketing",
 		Header:          "TLDR",
 		Agent:           "bot",
 		EnhancedHeaders: false,
+		ResolveLinks:    true,
+		MaxPerRun:       3,
+		MaxAge:          48 * time.Hour,
 	},
 	// {
 	// 	URL:             "https://search.cnbc.com/rs/search/combinedcms/view.xml?partnerId=wrss01&id=100727362",
@@ -115,6 +207,12 @@ var Feeds = []FeedConfig{
 		Header:          "investing.com",
 		Agent:           "bot",
 		EnhancedHeaders: false,
+		MaxAge:          48 * time.Hour,
+		Filters: []filters.Rule{
+			{Action: filters.Exclude, Match: filters.Regex, Value: `(?i)^earnings call( transcript)?:`},
+			{Action: filters.Exclude, Match: filters.Keyword, Value: "stock market today"},
+			{Action: filters.Exclude, Match: filters.Regex, Value: `(?i)\b(shares|stock) (rises|falls|edges (up|down)) \d`},
+		},
 	},
 	// {
 	// 	URL:             "https://www.propublica.org/feeds",
@@ -169,6 +267,11 @@ var Feeds = []FeedConfig{
 		Header:          "Antiwar",
 		Agent:           "bot",
 		EnhancedHeaders: false,
+		Filters: []filters.Rule{
+			{Action: filters.Exclude, Match: filters.Keyword, Value: "podcast"},
+			{Action: filters.Exclude, Match: filters.Keyword, Value: "donate"},
+			{Action: filters.Exclude, Match: filters.Category, Value: "Antiwar.com Radio"},
+		},
 	},
 	// {
 	// 	URL:             "https://www.reddit.com/r/worldnews/.rss",
@@ -0,0 +1,180 @@
+package filters
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"coreheadlines/expr"
+	"coreheadlines/typesPkg"
+	"coreheadlines/words"
+)
+
+type Action string
+
+const (
+	Include Action = "include" // when a scope has include rules, items must match one
+	Exclude Action = "exclude" // items matching any exclude rule are dropped
+)
+
+type Match string
+
+const (
+	Keyword  Match = "keyword"  // whole word/phrase in the title, case-insensitive
+	Regex    Match = "regex"    // RE2 pattern against the title; add (?i) for case-insensitive
+	Domain   Match = "domain"   // link host equals the value or is a subdomain of it
+	Author   Match = "author"   // author equals the value, case-insensitive
+	Category Match = "category" // any category equals the value, case-insensitive
+	Expr     Match = "expr"     // boolean expression over article fields, see package expr
+)
+
+type Rule struct {
+	Action Action
+	Match  Match
+	Value  string
+}
+
+func (r Rule) String() string {
+	return fmt.Sprintf("%s %s %q", r.Action, r.Match, r.Value)
+}
+
+type compiledRule struct {
+	Rule
+	scope string // "global" or the feed header
+	re    *regexp.Regexp
+	prog  *expr.Program
+}
+
+// Set is a compiled rule list for one feed: the global rules plus the
+// feed's own.
+type Set struct {
+	excludes       []compiledRule
+	feedIncludes   []compiledRule
+	globalIncludes []compiledRule
+}
+
+// Decision explains why an item was dropped.
+type Decision struct {
+	Drop   bool
+	Reason string // "excluded" or "not included"
+	Rule   string // the matching exclude rule, or the include scope
+}
+
+// Compile validates and compiles the global and per-feed rules.
+func Compile(global, feed []Rule, feedName string) (*Set, error) {
+	s := &Set{}
+
+	add := func(rules []Rule, scope string) error {
+		for i, r := range rules {
+			cr, err := compileRule(r, scope)
+			if err != nil {
+				return fmt.Errorf("%s filter #%d (%s): %w", scope, i+1, r, err)
+			}
+			switch {
+			case r.Action == Exclude:
+				s.excludes = append(s.excludes, cr)
+			case scope == "global":
+				s.globalIncludes = append(s.globalIncludes, cr)
+			default:
+				s.feedIncludes = append(s.feedIncludes, cr)
+			}
+		}
+		return nil
+	}
+
+	if err := add(global, "global"); err != nil {
+		return nil, err
+	}
+	if err := add(feed, feedName); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func compileRule(r Rule, scope string) (compiledRule, error) {
+	cr := compiledRule{Rule: r, scope: scope}
+
+	if r.Action != Include && r.Action != Exclude {
+		return cr, fmt.Errorf("unknown action %q", r.Action)
+	}
+	if strings.TrimSpace(r.Value) == "" {
+		return cr, fmt.Errorf("empty value")
+	}
+
+	switch r.Match {
+	case Keyword, Author, Category:
+		cr.Value = strings.ToLower(strings.TrimSpace(r.Value))
+	case Domain:
+		cr.Value = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(r.Value)), ".")
+	case Regex:
+		re, err := regexp.Compile(r.Value)
+		if err != nil {
+			return cr, err
+		}
+		cr.re = re
+	case Expr:
+		prog, err := expr.CompileBool(r.Value)
+		if err != nil {
+			return cr, err
+		}
+		cr.prog = prog
+	default:
+		return cr, fmt.Errorf("unknown match %q", r.Match)
+	}
+	return cr, nil
+}
+
+// Check decides whether art passes the set. Excludes win over includes.
+func (s *Set) Check(art typesPkg.MainStruct) Decision {
+	title := strings.ToLower(art.Title)
+
+	for _, r := range s.excludes {
+		if r.matches(art, title) {
+			return Decision{Drop: true, Reason: "excluded", Rule: r.scope + ": " + r.String()}
+		}
+	}
+	if len(s.feedIncludes) > 0 && !anyMatch(s.feedIncludes, art, title) {
+		return Decision{Drop: true, Reason: "not included", Rule: s.feedIncludes[0].scope + " include rules"}
+	}
+	if len(s.globalIncludes) > 0 && !anyMatch(s.globalIncludes, art, title) {
+		return Decision{Drop: true, Reason: "not included", Rule: "global include rules"}
+	}
+	return Decision{}
+}
+
+func anyMatch(rules []compiledRule, art typesPkg.MainStruct, title string) bool {
+	for _, r := range rules {
+		if r.matches(art, title) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r compiledRule) matches(art typesPkg.MainStruct, lowerTitle string) bool {
+	switch r.Match {
+	case Keyword:
+		return words.Contains(lowerTitle, r.Value)
+	case Regex:
+		return r.re.MatchString(art.Title)
+	case Expr:
+		return r.prog.Bool(art)
+	case Domain:
+		u, err := url.Parse(art.Link)
+		if err != nil {
+			return false
+		}
+		host := strings.ToLower(u.Hostname())
+		return host == r.Value || strings.HasSuffix(host, "."+r.Value)
+	case Author:
+		return strings.ToLower(strings.TrimSpace(art.Author)) == r.Value
+	case Category:
+		for _, c := range art.Categories {
+			if strings.ToLower(strings.TrimSpace(c)) == r.Value {
+				return true
+			}
+		}
+	}
+	return false
+}
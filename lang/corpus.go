@@ -0,0 +1,148 @@
+package lang
+
+// Training text for the trigram profiles: headline-style news prose with the
+// function words, inflections and accents each language actually shows in
+// titles. More text sharpens the profiles; keep the languages roughly the
+// same size so none is favoured.
+var corpus = map[string]string{
+	"en": `The government announced new sanctions against the country after the attack on Tuesday.
+Stocks fall as investors worry about inflation and higher interest rates.
+Apple unveils its latest iPhone with a faster chip and a better camera.
+Police say two people were killed and several others injured in the shooting.
+The president will meet with world leaders at the summit next week to discuss climate change.
+Scientists discover a new species of frog in the rainforest.
+How the startup raised millions of dollars from venture capital firms.
+Why the central bank is expected to cut rates again this year.
+Researchers found that the vulnerability could allow attackers to take control of the device.
+Microsoft is laying off thousands of workers as it shifts focus to artificial intelligence.
+The company said its profits were higher than expected in the third quarter.
+Thousands of people protest in the streets of the capital against the new law.
+Elections are being held across the region and the results will be announced tomorrow.
+What we know about the outbreak and how it is spreading.
+Oil prices rise after producers agree to reduce output.
+Show HN: I built an open source tool for developers that runs in the browser.
+The war has forced millions of families to leave their homes and seek shelter abroad.
+Court rules that the ban is unconstitutional and orders the state to pay damages.
+Hackers stole the personal data of millions of customers, the report says.
+Review: this is the best laptop you can buy right now, but it is not cheap.
+Officials warn of more storms and flooding over the weekend.
+Prime minister resigns amid scandal over government spending.
+Tech giants face new rules on data privacy and competition.
+Study shows that people who sleep more are healthier and happier.
+The minister said they would not comment on the investigation while it was ongoing.`,
+
+	"es": `El gobierno anunció nuevas sanciones contra el país tras el ataque del martes.
+Las bolsas caen porque los inversores temen la inflación y la subida de los tipos de interés.
+Apple presenta su nuevo iPhone con un procesador más rápido y una cámara mejor.
+La policía informa de que dos personas murieron y varias resultaron heridas en el tiroteo.
+El presidente se reunirá con los líderes mundiales en la cumbre de la próxima semana para hablar del cambio climático.
+Científicos descubren una nueva especie de rana en la selva.
+Cómo la empresa emergente consiguió millones de dólares de fondos de capital riesgo.
+¿Por qué se espera que el banco central vuelva a bajar los tipos este año?
+Los investigadores descubrieron que la vulnerabilidad permite a los atacantes tomar el control del dispositivo.
+Microsoft despide a miles de trabajadores mientras se centra en la inteligencia artificial.
+La compañía dijo que sus beneficios fueron mayores de lo esperado en el tercer trimestre.
+Miles de personas protestan en las calles de la capital contra la nueva ley.
+Se celebran elecciones en toda la región y los resultados se conocerán mañana.
+Lo que sabemos sobre el brote y cómo se está extendiendo.
+El precio del petróleo sube después de que los productores acuerden reducir la producción.
+He creado una herramienta de código abierto para desarrolladores que funciona en el navegador.
+La guerra ha obligado a millones de familias a abandonar sus hogares y buscar refugio en el extranjero.
+El tribunal dictamina que la prohibición es inconstitucional y ordena al estado pagar una indemnización.
+Unos piratas informáticos robaron los datos personales de millones de clientes, según el informe.
+Análisis: este es el mejor portátil que puedes comprar ahora mismo, pero no es barato.
+Las autoridades alertan de más tormentas e inundaciones durante el fin de semana.
+El primer ministro dimite en medio del escándalo por el gasto del gobierno.
+Los gigantes tecnológicos se enfrentan a nuevas normas sobre privacidad y competencia.
+Un estudio muestra que las personas que duermen más están más sanas y son más felices.
+España y México firman un acuerdo; según el ministro, no habrá más información hasta el año que viene.`,
+
+	"pt": `O governo anunciou novas sanções contra o país após o ataque de terça-feira.
+As bolsas caem porque os investidores temem a inflação e a alta dos juros.
+A Apple apresenta o seu novo iPhone com um processador mais rápido e uma câmera melhor.
+A polícia informou que duas pessoas morreram e várias ficaram feridas no tiroteio.
+O presidente vai se reunir com os líderes mundiais na cúpula da próxima semana para discutir as mudanças climáticas.
+Cientistas descobrem uma nova espécie de sapo na floresta tropical.
+Como a startup conseguiu milhões de dólares de fundos de capital de risco.
+Por que o banco central deve voltar a cortar os juros este ano?
+Os pesquisadores descobriram que a vulnerabilidade permite que os atacantes assumam o controle do dispositivo.
+A Microsoft demite milhares de funcionários enquanto foca em inteligência artificial.
+A empresa disse que os lucros foram maiores do que o esperado no terceiro trimestre.
+Milhares de pessoas protestam nas ruas da capital contra a nova lei.
+As eleições acontecem em toda a região e os resultados serão divulgados amanhã.
+O que sabemos sobre o surto e como ele está se espalhando.
+O preço do petróleo sobe depois que os produtores concordam em reduzir a produção.
+Criei uma ferramenta de código aberto para desenvolvedores que funciona no navegador.
+A guerra obrigou milhões de famílias a deixar as suas casas e procurar abrigo no exterior.
+O tribunal decide que a proibição é inconstitucional e manda o estado pagar uma indenização.
+Hackers roubaram os dados pessoais de milhões de clientes, segundo o relatório.
+Análise: este é o melhor notebook que você pode comprar agora, mas não é barato.
+As autoridades alertam para mais tempestades e inundações no fim de semana.
+O primeiro-ministro renuncia em meio ao escândalo sobre os gastos do governo.
+As gigantes de tecnologia enfrentam novas regras sobre privacidade e concorrência.
+Estudo mostra que as pessoas que dormem mais são mais saudáveis e felizes.
+Brasil e Portugal assinam acordo; segundo o ministro, não haverá mais informações até o ano que vem.`,
+
+	"fr": `Le gouvernement a annoncé de nouvelles sanctions contre le pays après l'attaque de mardi.
+Les bourses chutent car les investisseurs craignent l'inflation et la hausse des taux d'intérêt.
+Apple dévoile son nouvel iPhone avec une puce plus rapide et un meilleur appareil photo.
+Selon la police, deux personnes ont été tuées et plusieurs autres blessées dans la fusillade.
+Le président rencontrera les dirigeants mondiaux au sommet la semaine prochaine pour parler du changement climatique.
+Des scientifiques découvrent une nouvelle espèce de grenouille dans la forêt tropicale.
+Comment la jeune pousse a levé des millions de dollars auprès de fonds de capital-risque.
+Pourquoi la banque centrale devrait encore baisser ses taux cette année.
+Les chercheurs ont découvert que la faille permet aux attaquants de prendre le contrôle de l'appareil.
+Microsoft supprime des milliers d'emplois pour se concentrer sur l'intelligence artificielle.
+L'entreprise a déclaré que ses bénéfices étaient plus élevés que prévu au troisième trimestre.
+Des milliers de personnes manifestent dans les rues de la capitale contre la nouvelle loi.
+Des élections ont lieu dans toute la région et les résultats seront connus demain.
+Ce que l'on sait sur l'épidémie et comment elle se propage.
+Les prix du pétrole augmentent après l'accord des producteurs pour réduire la production.
+La guerre a forcé des millions de familles à quitter leur maison et à chercher refuge à l'étranger.
+Le tribunal juge que l'interdiction est inconstitutionnelle et condamne l'État à payer des dommages.
+Des pirates ont volé les données personnelles de millions de clients, selon le rapport.
+Les autorités mettent en garde contre de nouvelles tempêtes et des inondations ce week-end.
+Le Premier ministre démissionne au milieu d'un scandale sur les dépenses du gouvernement.`,
+
+	"de": `Die Regierung hat nach dem Angriff am Dienstag neue Sanktionen gegen das Land angekündigt.
+Die Börsen fallen, weil Anleger sich wegen der Inflation und steigender Zinsen sorgen.
+Apple stellt sein neues iPhone mit einem schnelleren Chip und einer besseren Kamera vor.
+Laut Polizei wurden bei der Schießerei zwei Menschen getötet und mehrere verletzt.
+Der Präsident trifft nächste Woche die Staats- und Regierungschefs auf dem Gipfel, um über den Klimawandel zu sprechen.
+Wissenschaftler entdecken eine neue Froschart im Regenwald.
+Wie das Startup Millionen von Dollar bei Risikokapitalgebern eingesammelt hat.
+Warum die Zentralbank die Zinsen in diesem Jahr erneut senken dürfte.
+Die Forscher fanden heraus, dass die Sicherheitslücke Angreifern die Kontrolle über das Gerät ermöglicht.
+Microsoft entlässt Tausende Mitarbeiter und setzt stärker auf künstliche Intelligenz.
+Das Unternehmen sagte, der Gewinn im dritten Quartal sei höher als erwartet gewesen.
+Tausende Menschen protestieren in den Straßen der Hauptstadt gegen das neue Gesetz.
+In der ganzen Region finden Wahlen statt, die Ergebnisse werden morgen bekannt gegeben.
+Was wir über den Ausbruch wissen und wie er sich ausbreitet.
+Der Ölpreis steigt, nachdem sich die Förderländer auf eine Kürzung der Produktion geeinigt haben.
+Der Krieg hat Millionen Familien gezwungen, ihre Häuser zu verlassen und im Ausland Schutz zu suchen.
+Das Gericht erklärt das Verbot für verfassungswidrig und verurteilt den Staat zu Schadenersatz.
+Hacker haben laut dem Bericht die persönlichen Daten von Millionen Kunden gestohlen.
+Die Behörden warnen am Wochenende vor weiteren Stürmen und Überschwemmungen.
+Der Ministerpräsident tritt inmitten eines Skandals über die Ausgaben der Regierung zurück.`,
+
+	"it": `Il governo ha annunciato nuove sanzioni contro il paese dopo l'attacco di martedì.
+Le borse scendono perché gli investitori temono l'inflazione e l'aumento dei tassi di interesse.
+Apple presenta il suo nuovo iPhone con un processore più veloce e una fotocamera migliore.
+Secondo la polizia, due persone sono state uccise e diverse altre ferite nella sparatoria.
+Il presidente incontrerà i leader mondiali al vertice della prossima settimana per parlare del cambiamento climatico.
+Gli scienziati scoprono una nuova specie di rana nella foresta pluviale.
+Come la startup ha raccolto milioni di dollari dai fondi di capitale di rischio.
+Perché la banca centrale dovrebbe tagliare di nuovo i tassi quest'anno.
+I ricercatori hanno scoperto che la vulnerabilità consente agli aggressori di prendere il controllo del dispositivo.
+Microsoft licenzia migliaia di dipendenti per concentrarsi sull'intelligenza artificiale.
+L'azienda ha detto che gli utili sono stati più alti del previsto nel terzo trimestre.
+Migliaia di persone protestano nelle strade della capitale contro la nuova legge.
+Si tengono elezioni in tutta la regione e i risultati saranno annunciati domani.
+Cosa sappiamo dell'epidemia e di come si sta diffondendo.
+Il prezzo del petrolio sale dopo che i produttori hanno deciso di ridurre la produzione.
+La guerra ha costretto milioni di famiglie a lasciare le loro case e cercare rifugio all'estero.
+Il tribunale stabilisce che il divieto è incostituzionale e condanna lo stato a pagare i danni.
+Gli hacker hanno rubato i dati personali di milioni di clienti, secondo il rapporto.
+Le autorità avvertono di altri temporali e alluvioni durante il fine settimana.
+Il primo ministro si dimette nel mezzo dello scandalo sulla spesa del governo.`,
+}
@@ -0,0 +1,154 @@
+// Package lang guesses the language of short texts such as headlines. It is
+// fully offline: a naive Bayes model over character trigrams, trained at
+// startup on the sample text in corpus.go.
+package lang
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	English    = "en"
+	Spanish    = "es"
+	Portuguese = "pt"
+	French     = "fr"
+	German     = "de"
+	Italian    = "it"
+)
+
+const (
+	// Below this many trigrams (roughly two short words) there is too
+	// little to go on.
+	minTrigrams = 8
+	// Average log-likelihood lead per trigram the winner needs over the
+	// runner-up; closer calls are reported as unknown.
+	minMargin = 0.1
+)
+
+// model is a smoothed unigram distribution over trigrams or words.
+type model struct {
+	logp   map[string]float64
+	unseen float64 // log-probability of an item missing from the corpus
+}
+
+// profile scores both character trigrams, which generalize to unseen
+// words, and whole words, which pin down short function words ("the",
+// "los", "dos") that trigrams alone weigh too lightly.
+type profile struct {
+	trigrams model
+	words    model
+}
+
+var (
+	profiles  = map[string]profile{}
+	languages []string
+)
+
+func init() {
+	for code, text := range corpus {
+		profiles[code] = profile{trigrams: train(trigrams(text)), words: train(words(text))}
+		languages = append(languages, code)
+	}
+	sort.Strings(languages)
+}
+
+func train(items []string) model {
+	counts := map[string]int{}
+	for _, it := range items {
+		counts[it]++
+	}
+
+	// Add-one smoothing; the denominator reserves room for unseen items
+	denom := math.Log(float64(len(items) + len(counts) + 1))
+	m := model{logp: make(map[string]float64, len(counts)), unseen: -denom}
+	for it, n := range counts {
+		m.logp[it] = math.Log(float64(n+1)) - denom
+	}
+	return m
+}
+
+func (m model) score(items []string) float64 {
+	total := 0.0
+	for _, it := range items {
+		if lp, ok := m.logp[it]; ok {
+			total += lp
+		} else {
+			total += m.unseen
+		}
+	}
+	return total
+}
+
+// Languages lists the supported language codes.
+func Languages() []string {
+	return append([]string(nil), languages...)
+}
+
+// Supported reports whether code is one of Languages.
+func Supported(code string) bool {
+	_, ok := profiles[code]
+	return ok
+}
+
+// Detect returns the most likely ISO 639-1 code for text, or ok=false when
+// the text is too short or the call is too close.
+func Detect(text string) (code string, ok bool) {
+	tris, ws := trigrams(text), words(text)
+	if len(tris) < minTrigrams {
+		return "", false
+	}
+
+	best, second := math.Inf(-1), math.Inf(-1)
+	for _, lang := range languages {
+		p := profiles[lang]
+		score := p.trigrams.score(tris) + p.words.score(ws)
+		switch {
+		case score > best:
+			second, best, code = best, score, lang
+		case score > second:
+			second = score
+		}
+	}
+
+	if (best-second)/float64(len(tris)) < minMargin {
+		return "", false
+	}
+	return code, true
+}
+
+// Guess is Detect with a fallback: the feed's declared language (hint) is
+// used when detection is unsure, which is common for terse titles.
+func Guess(text, hint string) string {
+	if code, ok := Detect(text); ok {
+		return code
+	}
+	return strings.ToLower(strings.TrimSpace(hint))
+}
+
+// IsEnglish treats unknown ("") as English, the language of most feeds.
+func IsEnglish(code string) bool {
+	return code == "" || code == English
+}
+
+// words splits text into lowercase runs of letters.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+}
+
+// trigrams returns the rune trigrams of each word padded with spaces, so
+// word starts and ends count.
+func trigrams(text string) []string {
+	var out []string
+	for _, w := range words(text) {
+		rs := []rune(" " + w + " ")
+		for i := 0; i+3 <= len(rs); i++ {
+			out = append(out, string(rs[i:i+3]))
+		}
+	}
+	return out
+}
@@ -2,16 +2,31 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"slices"
+	"sort"
+	"strconv"
 	"sync"
+	"time"
 
+	"coreheadlines/bot"
+	"coreheadlines/dedup"
 	"coreheadlines/dynamo"
+	"coreheadlines/expr"
 	"coreheadlines/feeds"
+	"coreheadlines/filters"
+	"coreheadlines/lang"
+	"coreheadlines/schedule"
 	"coreheadlines/telegram"
 	"coreheadlines/tools"
 	"coreheadlines/typesPkg"
 
+	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
@@ -53,6 +68,195 @@ func init() {
 	logger = setupLogger()
 }
 
+// *
+// **
+// ***
+// ****
+// ***** config
+type compiledRoute struct {
+	feeds.Route
+	when *expr.Program
+}
+
+type compiledPriority struct {
+	feeds.PriorityRule
+	when *expr.Program
+}
+
+// compiledConfig holds every rule and expression from the feeds package,
+// compiled once at startup.
+type compiledConfig struct {
+	filterSets []*filters.Set // indexed like feeds.Feeds
+	routes     []compiledRoute
+	priorities []compiledPriority
+}
+
+var compiled *compiledConfig
+
+func loadConfig() (*compiledConfig, error) {
+	c := &compiledConfig{filterSets: make([]*filters.Set, len(feeds.Feeds))}
+
+	// Edited emoji/country dictionaries, instead of the embedded ones
+	if dir := os.Getenv("EMOJI_DICT_DIR"); dir != "" {
+		problems, err := tools.LoadDictionaries(dir)
+		for _, p := range problems {
+			if p.Severity == tools.SeverityError {
+				logger.Error("Emoji dictionary problem", zap.String("problem", p.String()))
+			} else {
+				logger.Warn("Emoji dictionary problem", zap.String("problem", p.String()))
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("EMOJI_DICT_DIR %s: %w", dir, err)
+		}
+	}
+
+	opts, err := emojiOptions()
+	if err != nil {
+		return nil, err
+	}
+	tools.EmojiConfig = opts
+
+	for i, fc := range feeds.Feeds {
+		if fc.Language != "" && !lang.Supported(fc.Language) {
+			return nil, fmt.Errorf("feed %s: unsupported language %q (have %v)", fc.Header, fc.Language, lang.Languages())
+		}
+		rules := append(slices.Clone(fc.Filters), fc.HN.Rules()...)
+		set, err := filters.Compile(feeds.GlobalFilters, rules, fc.Header)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter config: %w", err)
+		}
+		c.filterSets[i] = set
+	}
+
+	for _, r := range feeds.Routes {
+		if r.Chat == "" {
+			return nil, fmt.Errorf("route %q: no chat", r.Name)
+		}
+		when, err := expr.CompileBool(r.When)
+		if err != nil {
+			return nil, fmt.Errorf("route %q: %w", r.Name, err)
+		}
+		c.routes = append(c.routes, compiledRoute{Route: r, when: when})
+	}
+
+	for i, pr := range feeds.PriorityRules {
+		when, err := expr.CompileBool(pr.When)
+		if err != nil {
+			return nil, fmt.Errorf("priority rule #%d: %w", i+1, err)
+		}
+		c.priorities = append(c.priorities, compiledPriority{PriorityRule: pr, when: when})
+	}
+
+	return c, nil
+}
+
+// emojiOptions reads EMOJI_MAX (emojis per title, 0 for none) and
+// EMOJI_FLAGS_FIRST (true/false); unset keeps the defaults.
+func emojiOptions() (tools.EmojiOptions, error) {
+	opts := tools.EmojiConfig
+	if v := os.Getenv("EMOJI_MAX"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return opts, fmt.Errorf("invalid EMOJI_MAX %q", v)
+		}
+		opts.Max = n
+	}
+	if v := os.Getenv("EMOJI_FLAGS_FIRST"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return opts, fmt.Errorf("invalid EMOJI_FLAGS_FIRST %q", v)
+		}
+		opts.FlagsFirst = b
+	}
+	return opts, nil
+}
+
+// mustLoadConfig stops the program on a bad rule, pointing at the offending
+// spot when it is an expression.
+func mustLoadConfig() *compiledConfig {
+	c, err := loadConfig()
+	if err != nil {
+		fields := []zap.Field{zap.Error(err)}
+		var exprErr *expr.Error
+		if errors.As(err, &exprErr) {
+			fields = append(fields, zap.String("expression", "\n"+exprErr.Caret()))
+		}
+		logger.Fatal("Invalid config", fields...)
+	}
+	return c
+}
+
+// prioritize scores articles against the priority rules and orders the batch
+// by priority, keeping the interleaved order among equals.
+func prioritize(c *compiledConfig, articles []typesPkg.MainStruct) {
+	if len(c.priorities) == 0 {
+		return
+	}
+	for i := range articles {
+		articles[i].Priority = 0
+		for _, pr := range c.priorities {
+			if pr.when.Bool(articles[i]) {
+				articles[i].Priority += pr.Priority
+			}
+		}
+	}
+	sort.SliceStable(articles, func(a, b int) bool {
+		return articles[a].Priority > articles[b].Priority
+	})
+}
+
+// routeCopies sends each published article to every route it matches.
+// Routes are best-effort extras: failures are logged, never retried.
+func routeCopies(ctx context.Context, c *compiledConfig, botToken, channelID string, published []typesPkg.MainStruct) {
+	for _, r := range c.routes {
+		var matched []typesPkg.MainStruct
+		for _, art := range published {
+			if r.when.Bool(art) {
+				matched = append(matched, art)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		sent, err := telegram.SendMessages(ctx, matched, botToken, r.Chat, telegram.SendOptions{BoostChannel: channelID})
+		if err != nil {
+			logger.Error("Route send failed",
+				zap.String("route", r.Name),
+				zap.Int("sent", sent),
+				zap.Int("matched", len(matched)),
+				zap.Error(err),
+			)
+			continue
+		}
+		logger.Info("Routed", zap.String("route", r.Name), zap.Int("sent", sent))
+	}
+}
+
+// *
+// **
+// ***
+// ****
+// ***** filter
+func applyFilters(set *filters.Set, articles []typesPkg.MainStruct) []typesPkg.MainStruct {
+	kept := articles[:0]
+	for _, art := range articles {
+		if d := set.Check(art); d.Drop {
+			logger.Info("Filtered out",
+				zap.String("guid", art.GUID),
+				zap.String("source", art.Header),
+				zap.String("title", art.Title),
+				zap.String("decision", d.Reason),
+				zap.String("rule", d.Rule),
+			)
+			continue
+		}
+		kept = append(kept, art)
+	}
+	return kept
+}
+
 // *
 // **
 // ***
@@ -64,20 +268,422 @@ func collectUnpublished(
 	db *dynamodb.Client,
 ) ([]typesPkg.MainStruct, error) {
 	toPublish := make([]typesPkg.MainStruct, 0, len(articles))
+	var migrated []typesPkg.MainStruct
 	for _, art := range articles {
 		pub, err := dynamo.IsArticlePublished(ctx, db, art.GUID)
 		if err != nil {
 			logger.Error("is-published check failed", zap.Error(err), zap.String("guid", art.GUID))
 			continue
 		}
+		// Stored before URL canonicalization under its raw GUID?
+		if !pub && art.LegacyGUID != "" {
+			pub, err = dynamo.IsArticlePublished(ctx, db, art.LegacyGUID)
+			if err != nil {
+				logger.Error("is-published check failed", zap.Error(err), zap.String("guid", art.LegacyGUID))
+				continue
+			}
+			if pub {
+				migrated = append(migrated, art)
+			}
+		}
 		if pub {
 			continue
 		}
 		toPublish = append(toPublish, art)
 	}
+
+	// Record the canonical GUID too so the legacy lookup is needed only once
+	if len(migrated) > 0 {
+		if err := dynamo.BatchMarkPublished(ctx, db, migrated); err != nil {
+			logger.Warn("Migrating legacy GUIDs failed", zap.Int("count", len(migrated)), zap.Error(err))
+		}
+	}
+
 	return toPublish, nil
 }
 
+// dropStale removes articles older than maxAge and returns them separately.
+// Undated articles age from their first sighting, which gives feeds without
+// dates the same grace period, and so do articles first seen while still
+// fresh: those were held back by the feed cap (see holdBack) and must not
+// be lost for waiting their turn. Lookup errors keep the article.
+func dropStale(ctx context.Context, db *dynamodb.Client, maxAge time.Duration, articles []typesPkg.MainStruct) (fresh, stale []typesPkg.MainStruct) {
+	now := time.Now()
+	fresh = articles[:0]
+
+	for _, art := range articles {
+		published := art.Published
+		if published.IsZero() || now.Sub(published) > maxAge {
+			seen, err := dynamo.FirstSeen(ctx, db, art.GUID, now)
+			if err != nil {
+				logger.Error("FirstSeen failed", zap.String("guid", art.GUID), zap.Error(err))
+				fresh = append(fresh, art)
+				continue
+			}
+			if published.IsZero() || seen.Sub(published) <= maxAge {
+				published = seen
+			}
+		}
+
+		if now.Sub(published) > maxAge {
+			logger.Info("Skipping stale article",
+				zap.String("guid", art.GUID),
+				zap.String("source", art.Header),
+				zap.String("title", art.Title),
+				zap.Time("published", published),
+				zap.Bool("dated", !art.Published.IsZero()),
+			)
+			stale = append(stale, art)
+			continue
+		}
+		fresh = append(fresh, art)
+	}
+	return fresh, stale
+}
+
+// holdBack records the first sighting of articles held back by a feed cap.
+// They stay unmarked, so later runs pick them up, and dropStale then ages
+// them from now rather than from their date.
+func holdBack(ctx context.Context, db *dynamodb.Client, articles []typesPkg.MainStruct) {
+	now := time.Now()
+	for _, art := range articles {
+		if _, err := dynamo.FirstSeen(ctx, db, art.GUID, now); err != nil {
+			logger.Error("FirstSeen failed", zap.String("guid", art.GUID), zap.Error(err))
+		}
+	}
+}
+
+// resolveRedirects replaces wrapper/shortener links with their final URL,
+// consulting the store's cache first. Failures keep the feed link.
+func resolveRedirects(ctx context.Context, db *dynamodb.Client, resolver *tools.LinkResolver, articles []typesPkg.MainStruct) {
+	for i, art := range articles {
+		if !tools.IsWebURL(art.Link) {
+			continue
+		}
+
+		target, cached, err := dynamo.GetResolvedLink(ctx, db, art.Link)
+		if err != nil {
+			logger.Warn("Resolved-link cache lookup failed", zap.String("link", art.Link), zap.Error(err))
+		}
+		if !cached {
+			target, err = resolver.Resolve(ctx, art.Link)
+			if err != nil {
+				logger.Warn("Link resolution incomplete", zap.String("link", art.Link), zap.Error(err))
+			}
+			if err == nil && target != "" {
+				if err := dynamo.PutResolvedLink(ctx, db, art.Link, target); err != nil {
+					logger.Warn("Caching resolved link failed", zap.String("link", art.Link), zap.Error(err))
+				}
+			}
+		}
+
+		if target != "" && target != art.Link {
+			articles[i].Link = target
+		}
+	}
+}
+
+// resolveCanonicalLinks swaps each link for the page's own rel=canonical
+// target when it declares one. Failures keep the feed link.
+func resolveCanonicalLinks(ctx context.Context, articles []typesPkg.MainStruct, userAgent string) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	for i, art := range articles {
+		if !tools.IsWebURL(art.Link) {
+			continue
+		}
+		canonical, err := tools.FetchRelCanonical(ctx, client, art.Link, userAgent)
+		if err != nil {
+			logger.Warn("rel=canonical lookup failed", zap.String("link", art.Link), zap.Error(err))
+			continue
+		}
+		if canonical != "" {
+			articles[i].Link = canonical
+		}
+	}
+}
+
+// *
+// **
+// ***
+// ****
+// ***** dedup
+const dedupWindow = 48 * time.Hour
+
+// story is one cluster: the article that is (or will be) posted for it and
+// where that article lives.
+type story struct {
+	recent *dynamo.RecentArticleRecord // lead was posted in an earlier run
+	batch  int                         // index into kept when the lead is new this run
+	added  bool                        // coverage grew this run
+}
+
+// clusterResult splits a batch into articles to post (their AlsoCoveredBy
+// filled with same-batch duplicates), duplicates that must just be marked
+// handled, and earlier posts whose coverage line has to be updated.
+type clusterResult struct {
+	Kept       []typesPkg.MainStruct
+	Duplicates []typesPkg.MainStruct
+	Updated    []dynamo.RecentArticleRecord
+
+	leadOf map[string]string // duplicate GUID -> GUID of its lead in Kept
+}
+
+// handledDuplicates returns the duplicates whose lead is in posted or was
+// posted on an earlier run. The others stay unmarked: their lead was held
+// back, and they cluster onto it again when it goes out.
+func (r clusterResult) handledDuplicates(posted []typesPkg.MainStruct) []typesPkg.MainStruct {
+	sent := make(map[string]bool, len(posted))
+	for _, art := range posted {
+		sent[art.GUID] = true
+	}
+	var handled []typesPkg.MainStruct
+	for _, dup := range r.Duplicates {
+		if lead, inBatch := r.leadOf[dup.GUID]; !inBatch || sent[lead] {
+			handled = append(handled, dup)
+		}
+	}
+	return handled
+}
+
+// clusterStories checks each candidate against recently published articles
+// and the ones already kept in this batch; the first article of a story is
+// posted, later ones only add "Also: <outlet>" to it.
+func clusterStories(
+	ctx context.Context,
+	db *dynamodb.Client,
+	articles []typesPkg.MainStruct,
+) clusterResult {
+	var res clusterResult
+	if len(articles) == 0 {
+		return res
+	}
+
+	det := dedup.NewDetector()
+	var stories []*story
+	err := dynamo.ScanRecent(ctx, db, time.Now().Add(-dedupWindow), func(rec dynamo.RecentArticleRecord) bool {
+		det.Add(rec.Article())
+		stories = append(stories, &story{recent: &rec, batch: -1})
+		return true
+	})
+	if err != nil {
+		// Degrade to in-batch detection only
+		logger.Error("Loading recent articles for dedup failed", zap.Error(err))
+		det = dedup.NewDetector()
+		stories = nil
+	}
+
+	res.Kept = make([]typesPkg.MainStruct, 0, len(articles))
+	res.leadOf = make(map[string]string)
+	for _, art := range articles {
+		idx, reason, dup := det.Match(art)
+		if !dup {
+			det.Add(art)
+			stories = append(stories, &story{batch: len(res.Kept)})
+			res.Kept = append(res.Kept, art)
+			continue
+		}
+
+		lead := det.Article(idx)
+		logger.Info("Clustered near-duplicate",
+			zap.String("guid", art.GUID),
+			zap.String("source", art.Header),
+			zap.String("title", art.Title),
+			zap.String("duplicate_of", lead.GUID),
+			zap.String("duplicate_source", lead.Header),
+			zap.String("reason", reason),
+		)
+		res.Duplicates = append(res.Duplicates, art)
+
+		st := stories[idx]
+		cov := typesPkg.Coverage{Header: art.Header, Link: art.Link}
+		if st.recent != nil {
+			if art.Header != st.recent.Header && !hasCoverage(st.recent.AlsoCoveredBy, art.Header) {
+				st.recent.AlsoCoveredBy = append(st.recent.AlsoCoveredBy, cov)
+				st.added = true
+			}
+		} else {
+			kept := &res.Kept[st.batch]
+			res.leadOf[art.GUID] = kept.GUID
+			if art.Header != kept.Header && !hasCoverage(kept.AlsoCoveredBy, art.Header) {
+				kept.AlsoCoveredBy = append(kept.AlsoCoveredBy, cov)
+			}
+		}
+	}
+
+	for _, st := range stories {
+		if st.recent != nil && st.added {
+			res.Updated = append(res.Updated, *st.recent)
+		}
+	}
+
+	return res
+}
+
+func hasCoverage(coverage []typesPkg.Coverage, header string) bool {
+	for _, c := range coverage {
+		if c.Header == header {
+			return true
+		}
+	}
+	return false
+}
+
+// updateCoverage stores the grown outlet list of earlier posts and edits the
+// channel messages to show it.
+func updateCoverage(ctx context.Context, db *dynamodb.Client, botToken, channelID string, updated []dynamo.RecentArticleRecord) {
+	for _, rec := range updated {
+		if err := dynamo.UpdateRecentCoverage(ctx, db, rec, rec.AlsoCoveredBy); err != nil {
+			logger.Error("UpdateRecentCoverage failed", zap.String("guid", rec.ArticleGUID), zap.Error(err))
+			continue
+		}
+		if rec.MessageID == 0 {
+			continue // went out in a digest, nothing to edit
+		}
+		if err := telegram.EditArticle(ctx, rec.Article(), botToken, channelID); err != nil {
+			logger.Error("Editing coverage failed", zap.String("guid", rec.ArticleGUID), zap.Error(err))
+			if errors.Is(err, telegram.ErrDeadlineNear) {
+				return
+			}
+		}
+	}
+}
+
+// *
+// **
+// ***
+// ****
+// ***** pacing
+// maxPostsPerRun reads the global cap MAX_POSTS_PER_RUN; 0 (or unset) means
+// no cap.
+func maxPostsPerRun() (int, error) {
+	v := os.Getenv("MAX_POSTS_PER_RUN")
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid MAX_POSTS_PER_RUN %q", v)
+	}
+	return n, nil
+}
+
+// interleave merges per-feed batches round-robin by source, so a source that
+// dumps its backlog cannot fill a whole run. Feeds sharing a header count as
+// one source; sources take turns in feed order, each oldest first.
+func interleave(batches [][]typesPkg.MainStruct) []typesPkg.MainStruct {
+	var order []string
+	bySource := make(map[string][]typesPkg.MainStruct)
+	total := 0
+	for _, batch := range batches {
+		for _, art := range batch {
+			if _, ok := bySource[art.Header]; !ok {
+				order = append(order, art.Header)
+			}
+			bySource[art.Header] = append(bySource[art.Header], art)
+			total++
+		}
+	}
+
+	for _, src := range order {
+		sortByDate(bySource[src])
+	}
+
+	out := make([]typesPkg.MainStruct, 0, total)
+	for round := 0; len(out) < total; round++ {
+		for _, src := range order {
+			if items := bySource[src]; round < len(items) {
+				out = append(out, items[round])
+			}
+		}
+	}
+	return out
+}
+
+// sortByDate orders one source's articles oldest first, so each source reads
+// as a timeline while sources still take turns. Undated articles count as
+// published now.
+func sortByDate(articles []typesPkg.MainStruct) {
+	now := time.Now()
+	when := func(a typesPkg.MainStruct) time.Time {
+		if a.Published.IsZero() {
+			return now
+		}
+		return a.Published
+	}
+	sort.SliceStable(articles, func(i, j int) bool {
+		return when(articles[i]).Before(when(articles[j]))
+	})
+}
+
+// *
+// **
+// ***
+// ****
+// ***** quiet hours
+const quietQueue = "quiet"
+
+func flushQuietQueue(ctx context.Context, db *dynamodb.Client, botToken, channelID string) error {
+	queued, err := dynamo.LoadQueue(ctx, db, quietQueue)
+	if err != nil {
+		return err
+	}
+	if len(queued) == 0 {
+		return nil
+	}
+
+	articles := make([]typesPkg.MainStruct, 0, len(queued))
+	for _, rec := range queued {
+		articles = append(articles, rec.Article())
+	}
+
+	// Only what went out leaves the queue, so a failed chunk is retried
+	// next run without resending the ones before it
+	sent, sendErr := telegram.SendDigest(ctx, articles, botToken, channelID, "🌙 While you were asleep")
+	if sent > 0 {
+		if err := dynamo.DeleteQueued(ctx, db, queued[:sent]); err != nil {
+			return errors.Join(sendErr, err)
+		}
+		logger.Info("Quiet-hours digest sent", zap.Int("articles", sent), zap.Int("queued", len(queued)))
+		afterPublish(ctx, db, botToken, channelID, articles[:sent])
+	}
+	return sendErr
+}
+
+// *
+// **
+// ***
+// ****
+// ***** after publish
+// afterPublish feeds the bot: remember what went out for /latest and /search,
+// DM subscribers whose tags match and copy posts to matching routes. Failures
+// here never fail the run.
+func afterPublish(ctx context.Context, db *dynamodb.Client, botToken, channelID string, published []typesPkg.MainStruct) {
+	// Recording what went out is quick and must survive a cancelled run
+	if err := dynamo.RecordRecent(context.WithoutCancel(ctx), db, published); err != nil {
+		logger.Error("RecordRecent failed", zap.Int("count", len(published)), zap.Error(err))
+	}
+
+	// Sends may take long: they keep the deadline so they stop in time
+	sendCtx, cancel := keepDeadline(ctx)
+	defer cancel()
+
+	if err := bot.New(db, botToken, channelID, os.Getenv("TELEGRAM_BOT_USERNAME")).NotifySubscribers(sendCtx, published); err != nil {
+		logger.Error("NotifySubscribers failed", zap.Error(err))
+	}
+
+	routeCopies(sendCtx, compiled, botToken, channelID, published)
+}
+
+// keepDeadline detaches ctx from cancellation but not from its deadline, so
+// work after publishing outlives a cancelled run yet never the Lambda.
+func keepDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached := context.WithoutCancel(ctx)
+	if dl, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(detached, dl)
+	}
+	return detached, func() {}
+}
+
 // *
 // **
 // ***
@@ -100,6 +706,13 @@ func runParsers(ctx context.Context, db *dynamodb.Client) error {
 		Reader: "RSSReader/1.0 (+https://github.com/genbraham/coreheadlines; " + email + ")",
 	}
 
+	maxPosts, err := maxPostsPerRun()
+	if err != nil {
+		return err
+	}
+
+	resolver := tools.NewLinkResolver(userAgents.Bot)
+
 	results := make([]feedResult, len(feeds.Feeds))
 	var wg sync.WaitGroup
 
@@ -118,6 +731,8 @@ func runParsers(ctx context.Context, db *dynamodb.Client) error {
 				return
 			}
 
+			articles = applyFilters(compiled.filterSets[i], articles)
+
 			toPub, err := collectUnpublished(ctx, articles, db)
 			if err != nil {
 				logger.Error("Error collecting unpublished articles",
@@ -128,32 +743,86 @@ func runParsers(ctx context.Context, db *dynamodb.Client) error {
 				return
 			}
 
+			if fc.MaxAge > 0 {
+				var stale []typesPkg.MainStruct
+				toPub, stale = dropStale(ctx, db, fc.MaxAge, toPub)
+				// Too old to ever post: stop reconsidering them
+				if err := dynamo.BatchMarkPublished(ctx, db, stale); err != nil {
+					logger.Error("BatchMarkPublished failed for stale articles",
+						zap.String("source", fc.Header),
+						zap.Int("count", len(stale)),
+						zap.Error(err),
+					)
+				}
+			}
+
+			// Oldest first, so the cap holds back the newest and the feed
+			// posts in order across runs
+			sortByDate(toPub)
+			if fc.MaxPerRun > 0 && len(toPub) > fc.MaxPerRun {
+				logger.Info("Holding back articles over the feed cap",
+					zap.String("source", fc.Header),
+					zap.Int("cap", fc.MaxPerRun),
+					zap.Int("held", len(toPub)-fc.MaxPerRun),
+				)
+				if fc.MaxAge > 0 {
+					holdBack(ctx, db, toPub[fc.MaxPerRun:])
+				}
+				toPub = toPub[:fc.MaxPerRun]
+			}
+
+			if fc.ResolveLinks {
+				resolveRedirects(ctx, db, resolver, toPub)
+			}
+			if fc.ResolveCanonical {
+				resolveCanonicalLinks(ctx, toPub, userAgents.Bot)
+			}
+
 			results[i].Articles = toPub
 		}(idx, cfg)
 	}
 
 	wg.Wait()
 
-	// Aggregate results preserving feed order
+	// Aggregate results, taking turns between sources
+	batches := make([][]typesPkg.MainStruct, 0, len(results))
+	for _, res := range results {
+		if res.Err == nil {
+			batches = append(batches, res.Articles)
+		}
+	}
+
 	allToPublish := make([]typesPkg.MainStruct, 0, 64)
 	seen := make(map[string]bool, 256)
 
-	for _, res := range results {
-		if res.Err != nil {
+	for _, art := range interleave(batches) {
+		if seen[art.GUID] {
 			continue
 		}
-		for _, art := range res.Articles {
-			if seen[art.GUID] {
-				continue
-			}
-			seen[art.GUID] = true
-			allToPublish = append(allToPublish, art)
-		}
+		seen[art.GUID] = true
+		allToPublish = append(allToPublish, art)
 	}
 
-	// Nothing new -> done
-	if len(allToPublish) == 0 {
-		return nil
+	// Same story from another source (different GUID) -> post the first,
+	// credit the rest on it
+	clusters := clusterStories(ctx, db, allToPublish)
+	allToPublish = clusters.Kept
+	prioritize(compiled, allToPublish)
+
+	if maxPosts > 0 && len(allToPublish) > maxPosts {
+		logger.Info("Holding back articles over the run cap",
+			zap.Int("cap", maxPosts),
+			zap.Int("held", len(allToPublish)-maxPosts),
+		)
+		allToPublish = allToPublish[:maxPosts]
+	}
+	if dups := clusters.handledDuplicates(allToPublish); len(dups) > 0 {
+		// Handled: never reconsider them on later runs
+		if err := dynamo.BatchMarkPublished(ctx, db, dups); err != nil {
+			logger.Error("BatchMarkPublished failed for duplicates",
+				zap.Int("count", len(dups)), zap.Error(err),
+			)
+		}
 	}
 
 	// Send to telegram
@@ -166,23 +835,84 @@ func runParsers(ctx context.Context, db *dynamodb.Client) error {
 		return fmt.Errorf("TELEGRAM_CHANNEL not set")
 	}
 
-	err := telegram.SendMessages(allToPublish, telegramBot, telegramChannel)
+	quiet, err := schedule.QuietHoursFromEnv()
 	if err != nil {
-		logger.Error("Error sending messages",
-			zap.Error(err),
-		)
 		return err
 	}
+	quietNow := quiet.Active(time.Now())
+
+	// Quiet hours just ended -> flush whatever was held back
+	if quiet.Mode == schedule.QuietDigest && !quietNow {
+		if err := flushQuietQueue(ctx, db, telegramBot, telegramChannel); err != nil {
+			logger.Error("Error flushing quiet-hours digest", zap.Error(err))
+		}
+	}
+
+	// Nothing new -> done
+	if len(allToPublish) == 0 {
+		updateCoverage(ctx, db, telegramBot, telegramChannel, clusters.Updated)
+		return nil
+	}
+
+	if quietNow && quiet.Mode == schedule.QuietDigest {
+		if err := dynamo.EnqueueArticles(ctx, db, quietQueue, allToPublish); err != nil {
+			logger.Error("EnqueueArticles failed", zap.Int("count", len(allToPublish)), zap.Error(err))
+			return err
+		}
+		// Queued articles count as handled; the digest delivers them later
+		if err := dynamo.BatchMarkPublished(ctx, db, allToPublish); err != nil {
+			logger.Error("BatchMarkPublished failed after enqueue",
+				zap.Int("count", len(allToPublish)), zap.Error(err),
+			)
+			return err
+		}
+		logger.Info("Quiet hours: articles queued for digest", zap.Int("queued", len(allToPublish)))
+		updateCoverage(ctx, db, telegramBot, telegramChannel, clusters.Updated)
+		return nil
+	}
+
+	opts := telegram.SendOptions{DisableNotification: quietNow && quiet.Mode == schedule.QuietSilent}
+	sent, sendErr := telegram.SendMessages(ctx, allToPublish, telegramBot, telegramChannel, opts)
 
-	// Mark published
-	if err := dynamo.BatchMarkPublished(ctx, db, allToPublish); err != nil {
-		logger.Error("BatchMarkPublished failed after send",
-			zap.Int("count", len(allToPublish)), zap.Error(err),
+	// Mark what actually went out, even if the run was cut short; the unsent
+	// remainder stays unmarked and is picked up by the next invocation.
+	if sent > 0 {
+		if err := dynamo.BatchMarkPublished(context.WithoutCancel(ctx), db, allToPublish[:sent]); err != nil {
+			logger.Error("BatchMarkPublished failed after send",
+				zap.Int("count", sent), zap.Error(err),
+			)
+			return err
+		}
+	}
+
+	if sent > 0 && sendErr == nil {
+		afterPublish(ctx, db, telegramBot, telegramChannel, allToPublish[:sent])
+	} else if sent > 0 {
+		// Out of time or failing: keep the store in sync, skip the DMs
+		if err := dynamo.RecordRecent(context.WithoutCancel(ctx), db, allToPublish[:sent]); err != nil {
+			logger.Error("RecordRecent failed", zap.Int("count", sent), zap.Error(err))
+		}
+	}
+
+	if sendErr == nil {
+		updateCoverage(ctx, db, telegramBot, telegramChannel, clusters.Updated)
+	}
+
+	if errors.Is(sendErr, telegram.ErrDeadlineNear) {
+		logger.Warn("Stopping before deadline",
+			zap.Int("sent", sent), zap.Int("deferred", len(allToPublish)-sent),
 		)
-		return err
+		return nil
+	}
+	if sendErr != nil {
+		logger.Error("Error sending messages",
+			zap.Int("sent", sent),
+			zap.Error(sendErr),
+		)
+		return sendErr
 	}
 
-	logger.Info("Run complete", zap.Int("new_articles", len(allToPublish)))
+	logger.Info("Run complete", zap.Int("new_articles", sent), zap.Bool("silent", opts.DisableNotification))
 
 	return nil
 }
@@ -198,12 +928,75 @@ func logic(ctx context.Context) error {
 	return runParsers(ctx, db)
 }
 
+// *
+// **
+// ***
+// ****
+// ***** bot
+func newBot(ctx context.Context) (*bot.Bot, error) {
+	sdkConfig, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load SDK config: %v", err)
+	}
+
+	telegramBot := os.Getenv("TELEGRAM_BOT")
+	if telegramBot == "" {
+		return nil, fmt.Errorf("TELEGRAM_BOT not set")
+	}
+	telegramChannel := os.Getenv("TELEGRAM_CHANNEL")
+	if telegramChannel == "" {
+		return nil, fmt.Errorf("TELEGRAM_CHANNEL not set")
+	}
+
+	return bot.New(dynamodb.NewFromConfig(sdkConfig), telegramBot, telegramChannel, os.Getenv("TELEGRAM_BOT_USERNAME")), nil
+}
+
+// webhookHandler serves Telegram updates behind a Lambda function URL or an
+// API Gateway HTTP API (both deliver the same payload shape). Telegram
+// retries anything but 2xx, so handler errors are logged and acknowledged.
+func webhookHandler(b *bot.Bot) func(context.Context, events.LambdaFunctionURLRequest) (events.LambdaFunctionURLResponse, error) {
+	secret := os.Getenv("TELEGRAM_WEBHOOK_SECRET")
+
+	return func(ctx context.Context, req events.LambdaFunctionURLRequest) (events.LambdaFunctionURLResponse, error) {
+		if secret != "" && req.Headers["x-telegram-bot-api-secret-token"] != secret {
+			return events.LambdaFunctionURLResponse{StatusCode: http.StatusUnauthorized}, nil
+		}
+
+		body := []byte(req.Body)
+		if req.IsBase64Encoded {
+			decoded, err := base64.StdEncoding.DecodeString(req.Body)
+			if err != nil {
+				return events.LambdaFunctionURLResponse{StatusCode: http.StatusBadRequest}, nil
+			}
+			body = decoded
+		}
+
+		if err := b.HandleWebhook(ctx, body); err != nil {
+			logger.Error("Error handling update", zap.Error(err))
+		}
+		return events.LambdaFunctionURLResponse{StatusCode: http.StatusOK}, nil
+	}
+}
+
 func main() {
 	ctx := context.Background()
 	defer logger.Sync()
 
+	poll := flag.Bool("poll", false, "run the interactive bot with long polling instead of a publish run")
+	flag.Parse()
+
 	if os.Getenv("AWS_LAMBDA_RUNTIME_API") != "" {
 		// Running in Lambda
+		if os.Getenv("BOT_MODE") == "webhook" {
+			b, err := newBot(ctx)
+			if err != nil {
+				logger.Fatal("Bot setup failed", zap.Error(err))
+			}
+			lambda.Start(webhookHandler(b))
+			return
+		}
+
+		compiled = mustLoadConfig()
 		lambda.Start(func(ctx context.Context) error {
 			return logic(ctx)
 		})
@@ -216,6 +1009,19 @@ func main() {
 			)
 		}
 
+		if *poll {
+			b, err := newBot(ctx)
+			if err != nil {
+				logger.Fatal("Bot setup failed", zap.Error(err))
+			}
+			logger.Info("Polling for bot updates")
+			err = b.Poll(ctx, func(err error) {
+				logger.Error("Bot update failed", zap.Error(err))
+			})
+			logger.Fatal("Polling stopped", zap.Error(err))
+		}
+
+		compiled = mustLoadConfig()
 		if err := logic(ctx); err != nil {
 			logger.Fatal("Application failed",
 				zap.Error(err),
@@ -0,0 +1,94 @@
+package schedule
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+type QuietMode string
+
+const (
+	QuietOff    QuietMode = ""
+	QuietSilent QuietMode = "silent" // send with disable_notification=true
+	QuietDigest QuietMode = "digest" // queue, flush as one digest when the window ends
+)
+
+type QuietHours struct {
+	Start    time.Duration // offset from local midnight
+	End      time.Duration // offset from local midnight; may be < Start (overnight)
+	Location *time.Location
+	Mode     QuietMode
+}
+
+// QuietHoursFromEnv reads QUIET_HOURS ("23:00-07:00"), QUIET_TZ (IANA name,
+// default UTC) and QUIET_MODE ("silent" or "digest"). An empty QUIET_HOURS
+// disables the policy.
+func QuietHoursFromEnv() (QuietHours, error) {
+	window := strings.TrimSpace(os.Getenv("QUIET_HOURS"))
+	if window == "" {
+		return QuietHours{}, nil
+	}
+
+	startStr, endStr, ok := strings.Cut(window, "-")
+	if !ok {
+		return QuietHours{}, fmt.Errorf("QUIET_HOURS %q: expected HH:MM-HH:MM", window)
+	}
+	start, err := parseClock(startStr)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("QUIET_HOURS start: %w", err)
+	}
+	end, err := parseClock(endStr)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("QUIET_HOURS end: %w", err)
+	}
+
+	loc := time.UTC
+	if tz := strings.TrimSpace(os.Getenv("QUIET_TZ")); tz != "" {
+		loc, err = time.LoadLocation(tz)
+		if err != nil {
+			return QuietHours{}, fmt.Errorf("QUIET_TZ %q: %w", tz, err)
+		}
+	}
+
+	mode := QuietMode(strings.ToLower(strings.TrimSpace(os.Getenv("QUIET_MODE"))))
+	switch mode {
+	case QuietOff:
+		mode = QuietSilent
+	case QuietSilent, QuietDigest:
+	default:
+		return QuietHours{}, fmt.Errorf("QUIET_MODE %q: expected %q or %q", mode, QuietSilent, QuietDigest)
+	}
+
+	return QuietHours{Start: start, End: end, Location: loc, Mode: mode}, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q: %w", s, err)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func (q QuietHours) Enabled() bool {
+	return q.Mode != QuietOff && q.Start != q.End
+}
+
+// Active reports whether t falls inside the quiet window in q's timezone.
+func (q QuietHours) Active(t time.Time) bool {
+	if !q.Enabled() {
+		return false
+	}
+
+	local := t.In(q.Location)
+	y, m, d := local.Date()
+	offset := local.Sub(time.Date(y, m, d, 0, 0, 0, 0, q.Location))
+
+	if q.Start < q.End {
+		return offset >= q.Start && offset < q.End
+	}
+	// window wraps midnight, e.g. 23:00-07:00
+	return offset >= q.Start || offset < q.End
+}
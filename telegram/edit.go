@@ -0,0 +1,71 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"coreheadlines/typesPkg"
+)
+
+type sentMessage struct {
+	Result struct {
+		MessageID int64 `json:"message_id"`
+	} `json:"result"`
+}
+
+func messageID(body []byte) int64 {
+	var out sentMessage
+	if err := json.Unmarshal(body, &out); err != nil {
+		return 0
+	}
+	return out.Result.MessageID
+}
+
+// EditArticle re-renders an already posted article in place, e.g. after more
+// outlets covered the story. The keyboard is sent again because an edit
+// without reply_markup would remove it.
+func EditArticle(ctx context.Context, p typesPkg.MainStruct, botToken, channelID string) error {
+	if p.MessageID == 0 {
+		return fmt.Errorf("edit GUID %q: no message_id recorded", p.GUID)
+	}
+	if err := checkDeadline(ctx); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("https://api.telegram.org/bot%s/editMessageText", botToken)
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	form := url.Values{}
+	form.Set("chat_id", channelID)
+	form.Set("message_id", strconv.FormatInt(p.MessageID, 10))
+	form.Set("text", ensureMaxLen(buildTelegramHTML(p), telegramMaxLen))
+	form.Set("parse_mode", "HTML")
+	if replyMarkup, _ := buildInlineKeyboard(p, channelID); replyMarkup != "" {
+		form.Set("reply_markup", replyMarkup)
+	}
+	if lpoJSON, err := buildLinkPreviewOptionsJSON(p); err == nil && lpoJSON != "" {
+		form.Set("link_preview_options", lpoJSON)
+	}
+
+	_, err := postWithRetry(ctx, client, endpoint, form, p.GUID)
+	if isNotModified(err) {
+		return nil
+	}
+	return err
+}
+
+// isNotModified reports Telegram's answer to an edit that changes nothing,
+// which for us means the post is already up to date.
+func isNotModified(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) &&
+		apiErr.tg.ErrorCode == http.StatusBadRequest &&
+		strings.Contains(apiErr.tg.Description, "message is not modified")
+}
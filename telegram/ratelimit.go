@@ -0,0 +1,161 @@
+package telegram
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Telegram's documented limits: ~30 messages/second overall and ~20
+// messages/minute into the same group or channel.
+const (
+	globalPerSecond = 30.0
+	globalBurst     = 30
+	chatPerMinute   = 20.0
+	chatBurst       = 3
+
+	// AIMD tuning when Telegram answers with retry_after
+	penaltyFactor = 0.5  // multiplicative decrease of the chat rate
+	recoveryStep  = 0.1  // additive increase (fraction of the base rate) per success
+	minRateFactor = 0.25 // never go below a quarter of the base rate
+)
+
+type bucket struct {
+	tokens       float64
+	capacity     float64
+	rate         float64 // tokens per second
+	baseRate     float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+func newBucket(ratePerSec float64, capacity int, now time.Time) *bucket {
+	return &bucket{
+		tokens:   float64(capacity),
+		capacity: float64(capacity),
+		rate:     ratePerSec,
+		baseRate: ratePerSec,
+		last:     now,
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(b.capacity, b.tokens+elapsed*b.rate)
+	}
+	b.last = now
+}
+
+// delay returns how long until one token is available (0 if now).
+func (b *bucket) delay(now time.Time) time.Duration {
+	var d time.Duration
+	if b.blockedUntil.After(now) {
+		d = b.blockedUntil.Sub(now)
+	}
+	if b.tokens < 1 {
+		d = max(d, time.Duration((1-b.tokens)/b.rate*float64(time.Second)))
+	}
+	return d
+}
+
+// RateLimiter is a token bucket per chat plus one global bucket, so every
+// send path (channel posts, digests, bot replies) shares the same budget.
+type RateLimiter struct {
+	mu     sync.Mutex
+	global *bucket
+	chats  map[string]*bucket
+}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		global: newBucket(globalPerSecond, globalBurst, time.Now()),
+		chats:  make(map[string]*bucket),
+	}
+}
+
+var limiter = NewRateLimiter()
+
+func (l *RateLimiter) chat(chatID string, now time.Time) *bucket {
+	b, ok := l.chats[chatID]
+	if !ok {
+		b = newBucket(chatPerMinute/60, chatBurst, now)
+		l.chats[chatID] = b
+	}
+	return b
+}
+
+// bucketFor is the bucket a retry_after or a success for chatID applies to:
+// the chat's own, or the global one for calls outside any chat, since that is
+// the only one reserve checks for them.
+func (l *RateLimiter) bucketFor(chatID string, now time.Time) *bucket {
+	if chatID == "" {
+		return l.global
+	}
+	return l.chat(chatID, now)
+}
+
+// reserve takes a token from both buckets if possible, otherwise it reports
+// how long the caller has to wait before trying again. Calls that don't post
+// into a chat (empty chatID, e.g. answerCallbackQuery) only use the global one.
+func (l *RateLimiter) reserve(chatID string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.global.refill(now)
+	if chatID == "" {
+		if d := l.global.delay(now); d > 0 {
+			return d
+		}
+		l.global.tokens--
+		return 0
+	}
+
+	c := l.chat(chatID, now)
+	c.refill(now)
+
+	if d := max(l.global.delay(now), c.delay(now)); d > 0 {
+		return d
+	}
+	l.global.tokens--
+	c.tokens--
+	return 0
+}
+
+// Wait blocks until a message may be sent to chatID or ctx is done. It
+// returns ErrDeadlineNear rather than wait past ctx's deadline margin.
+func (l *RateLimiter) Wait(ctx context.Context, chatID string) error {
+	for {
+		d := l.reserve(chatID)
+		if d == 0 {
+			return nil
+		}
+		if err := sleepCtx(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// Penalize records a retry_after answer: the chat is blocked for the given
+// duration and its pace is cut so the next sends don't trip the limit again.
+// With an empty chatID the global bucket takes the penalty.
+func (l *RateLimiter) Penalize(chatID string, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	c := l.bucketFor(chatID, now)
+	c.refill(now)
+	c.blockedUntil = now.Add(retryAfter)
+	c.rate = max(c.rate*penaltyFactor, c.baseRate*minRateFactor)
+	c.tokens = min(c.tokens, 0)
+}
+
+// Success slowly restores a penalized chat back to its base pace.
+func (l *RateLimiter) Success(chatID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	c := l.bucketFor(chatID, time.Now())
+	c.rate = min(c.rate+c.baseRate*recoveryStep, c.baseRate)
+}
@@ -0,0 +1,44 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPenalizeEmptyChatBlocksGlobal(t *testing.T) {
+	l := NewRateLimiter()
+	if d := l.reserve(""); d != 0 {
+		t.Fatalf("fresh limiter: reserve waits %v", d)
+	}
+
+	l.Penalize("", 100*time.Millisecond)
+	if d := l.reserve(""); d <= 0 {
+		t.Errorf("after Penalize(\"\"): reserve(\"\") = %v, want a wait", d)
+	}
+	if _, ok := l.chats[""]; ok {
+		t.Error("Penalize(\"\") created a chat bucket nothing reads")
+	}
+
+	start := time.Now()
+	if err := l.Wait(context.Background(), ""); err != nil {
+		t.Fatal(err)
+	}
+	if waited := time.Since(start); waited < 80*time.Millisecond {
+		t.Errorf("Wait returned after %v, want about the 100ms retry_after", waited)
+	}
+}
+
+func TestPenalizeChat(t *testing.T) {
+	l := NewRateLimiter()
+	l.Penalize("42", 100*time.Millisecond)
+	if d := l.reserve("42"); d <= 0 {
+		t.Errorf("penalized chat: reserve = %v, want a wait", d)
+	}
+	if d := l.reserve("43"); d != 0 {
+		t.Errorf("other chat: reserve = %v, want none", d)
+	}
+	if d := l.reserve(""); d != 0 {
+		t.Errorf("no chat: reserve = %v, want none", d)
+	}
+}
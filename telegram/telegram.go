@@ -1,12 +1,16 @@
 package telegram
 
 import (
+	"context"
 	"coreheadlines/tools"
 	"coreheadlines/typesPkg"
 	"math/rand"
 	"strconv"
 
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html"
 	"io"
@@ -18,6 +22,24 @@ import (
 
 const telegramMaxLen = 4096
 
+// BoostCallbackPrefix marks the fallback Boost button's callback_data,
+// followed by the BoostID of the article.
+const BoostCallbackPrefix = "boost:"
+
+// BoostID is a short stable ID for an article GUID. GUIDs are often URLs
+// too long for Telegram's 64-byte callback_data; the ID always fits, and
+// the article's Boost clicks are counted under it.
+func BoostID(guid string) string {
+	sum := sha256.Sum256([]byte(guid))
+	return hex.EncodeToString(sum[:12])
+}
+
+// IsBoostID reports whether s has the form BoostID returns.
+func IsBoostID(s string) bool {
+	_, err := hex.DecodeString(s)
+	return err == nil && len(s) == 24
+}
+
 const (
 	maxAttempts  = 3
 	baseBackoff  = 1 * time.Second
@@ -42,6 +64,24 @@ type tgAPIError struct {
 	} `json:"parameters"`
 }
 
+// apiError is a Bot API call that failed for good, with Telegram's answer
+// decoded when it sent one.
+type apiError struct {
+	status int
+	body   string
+	tg     tgAPIError
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("telegram API status %d: %s", e.status, e.body)
+}
+
+// SendOptions tweaks how a batch is delivered.
+type SendOptions struct {
+	DisableNotification bool   // deliver silently (quiet hours)
+	BoostChannel        string // channel the Boost button points at; defaults to the target chat
+}
+
 func boolp(b bool) *bool { return &b }
 
 func buildLinkPreviewOptionsJSON(p typesPkg.MainStruct) (string, error) {
@@ -63,19 +103,49 @@ func buildLinkPreviewOptionsJSON(p typesPkg.MainStruct) (string, error) {
 	return string(b), nil
 }
 
-func SendMessages(posts []typesPkg.MainStruct, botToken, channelID string) error {
+// ErrDeadlineNear is returned when a send loop stops early because the
+// context deadline (e.g. the Lambda timeout) is too close to risk another post.
+var ErrDeadlineNear = errors.New("telegram: deadline too close, stopping early")
+
+// deadlineMargin is the time kept in hand after the last post: one full
+// request timeout plus room for the caller to record what was sent.
+const deadlineMargin = 20 * time.Second
+
+func checkDeadline(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if dl, ok := ctx.Deadline(); ok && time.Until(dl) < deadlineMargin {
+		return ErrDeadlineNear
+	}
+	return nil
+}
+
+// SendMessages posts each article in order and returns how many were
+// delivered. On error (including ErrDeadlineNear) posts[:sent] went out and
+// the remainder did not. The message_id of each delivered post is stored in
+// posts[i].MessageID.
+func SendMessages(ctx context.Context, posts []typesPkg.MainStruct, botToken, channelID string, opts SendOptions) (int, error) {
 	if len(posts) == 0 {
-		return nil
+		return 0, nil
 	}
 
 	endpoint := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", botToken)
 	client := &http.Client{Timeout: 15 * time.Second}
 
 	for i, p := range posts {
+		if err := checkDeadline(ctx); err != nil {
+			return i, err
+		}
+
 		text := buildTelegramHTML(p)
 		text = ensureMaxLen(text, telegramMaxLen)
 
-		replyMarkup, _ := buildInlineKeyboard(p, channelID)
+		boostChannel := channelID
+		if opts.BoostChannel != "" {
+			boostChannel = opts.BoostChannel
+		}
+		replyMarkup, _ := buildInlineKeyboard(p, boostChannel)
 
 		form := url.Values{}
 		form.Set("chat_id", channelID)
@@ -84,41 +154,97 @@ func SendMessages(posts []typesPkg.MainStruct, botToken, channelID string) error
 		if replyMarkup != "" {
 			form.Set("reply_markup", replyMarkup)
 		}
+		if opts.DisableNotification {
+			form.Set("disable_notification", "true")
+		}
 		if lpoJSON, err := buildLinkPreviewOptionsJSON(p); err == nil && lpoJSON != "" {
 			form.Set("link_preview_options", lpoJSON)
 		}
 
-		if err := postWithRetry(client, endpoint, form, p.GUID); err != nil {
-			return err
+		body, err := postWithRetry(ctx, client, endpoint, form, p.GUID)
+		if err != nil {
+			return i, err
 		}
+		posts[i].MessageID = messageID(body)
+	}
+	return len(posts), nil
+}
+
+// SendDigest posts the given articles as a compact list, split across as
+// many messages as the Telegram length limit requires, and returns how many
+// were delivered. On error posts[:sent] went out and the remainder did not.
+func SendDigest(ctx context.Context, posts []typesPkg.MainStruct, botToken, channelID, title string) (int, error) {
+	if len(posts) == 0 {
+		return 0, nil
+	}
 
-		if i < len(posts)-1 {
-			time.Sleep(1500 * time.Millisecond)
+	endpoint := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", botToken)
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	chunks, counts := buildDigestHTML(posts, title, telegramMaxLen)
+	sent := 0
+	for i, text := range chunks {
+		if err := checkDeadline(ctx); err != nil {
+			return sent, err
 		}
+
+		form := url.Values{}
+		form.Set("chat_id", channelID)
+		form.Set("text", text)
+		form.Set("parse_mode", "HTML")
+		form.Set("link_preview_options", `{"is_disabled":true}`)
+
+		if _, err := postWithRetry(ctx, client, endpoint, form, fmt.Sprintf("digest#%d", i+1)); err != nil {
+			return sent, err
+		}
+		sent += counts[i]
 	}
-	return nil
+	return sent, nil
 }
 
-func postWithRetry(client *http.Client, endpoint string, form url.Values, guid string) error {
+// postWithRetry performs one Bot API call and returns the raw response body.
+func postWithRetry(ctx context.Context, client *http.Client, endpoint string, form url.Values, guid string) ([]byte, error) {
 	var lastErr error
+	chatID := form.Get("chat_id")
 
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		resp, err := client.PostForm(endpoint, form)
+		// Shared pacing across every chat we post to
+		if err := limiter.Wait(ctx, chatID); err != nil {
+			return nil, err
+		}
+		// Every attempt needs room for the full client timeout
+		if err := checkDeadline(ctx); err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		resp, err := client.Do(req)
 		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
 			// network issue -> retryable
 			lastErr = fmt.Errorf("sendMessage failed for GUID %q: %w", guid, err)
 			if attempt < maxAttempts {
-				time.Sleep(backoffDelay(attempt))
+				if err := sleepCtx(ctx, backoffDelay(attempt)); err != nil {
+					return nil, err
+				}
 				continue
 			}
-			return lastErr
+			return nil, lastErr
 		}
 
 		body, _ := io.ReadAll(resp.Body)
 		_ = resp.Body.Close()
 
 		if resp.StatusCode == http.StatusOK {
-			return nil
+			limiter.Success(chatID)
+			return body, nil
 		}
 
 		apiErr := tgAPIError{}
@@ -126,35 +252,59 @@ func postWithRetry(client *http.Client, endpoint string, form url.Values, guid s
 
 		// Respect explicit retry_after if present (FloodWait / rate limit)
 		if (resp.StatusCode == http.StatusTooManyRequests || apiErr.ErrorCode == http.StatusTooManyRequests) && apiErr.Parameters.RetryAfter > 0 {
+			limiter.Penalize(chatID, time.Duration(apiErr.Parameters.RetryAfter)*time.Second)
 			if attempt < maxAttempts {
-				time.Sleep(time.Duration(apiErr.Parameters.RetryAfter) * time.Second)
 				continue
 			}
-			return fmt.Errorf("telegram rate limited (retry_after=%ds) for GUID %q: %s", apiErr.Parameters.RetryAfter, guid, string(body))
+			return nil, fmt.Errorf("telegram rate limited (retry_after=%ds) for GUID %q: %s", apiErr.Parameters.RetryAfter, guid, string(body))
 		}
 
 		// Respect Retry-After header if provided
 		if ra := resp.Header.Get("Retry-After"); ra != "" {
-			if secs, err := strconv.Atoi(ra); err == nil && secs > 0 && attempt < maxAttempts {
-				time.Sleep(time.Duration(secs) * time.Second)
-				continue
+			if secs, err := strconv.Atoi(ra); err == nil && secs > 0 {
+				limiter.Penalize(chatID, time.Duration(secs)*time.Second)
+				if attempt < maxAttempts {
+					continue
+				}
 			}
 		}
 
 		if resp.StatusCode >= 500 && resp.StatusCode <= 599 {
-			lastErr = fmt.Errorf("telegram API status %d: %s", resp.StatusCode, string(body))
+			lastErr = &apiError{status: resp.StatusCode, body: string(body), tg: apiErr}
 			if attempt < maxAttempts {
-				time.Sleep(backoffDelay(attempt))
+				if err := sleepCtx(ctx, backoffDelay(attempt)); err != nil {
+					return nil, err
+				}
 				continue
 			}
-			return lastErr
+			return nil, lastErr
 		}
 
-		return fmt.Errorf("telegram API status %d: %s", resp.StatusCode, string(body))
+		return nil, &apiError{status: resp.StatusCode, body: string(body), tg: apiErr}
 	}
 
 	// Should not reach here
-	return lastErr
+	return nil, lastErr
+}
+
+// sleepCtx waits d, or returns ErrDeadlineNear straight away when waiting
+// would leave less than deadlineMargin before ctx's deadline: a rate limit
+// penalty or backoff must not sleep the Lambda into its timeout before the
+// caller has recorded what was sent.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if dl, ok := ctx.Deadline(); ok && time.Until(dl)-d < deadlineMargin {
+		return ErrDeadlineNear
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
 }
 
 func backoffDelay(attempt int) time.Duration {
@@ -173,7 +323,7 @@ func buildTelegramHTML(p typesPkg.MainStruct) string {
 	rawTitle := strings.TrimSpace(p.Title)
 	header := strings.TrimSpace(p.Header)
 
-	emojis := strings.TrimSpace(tools.GetEmojis(rawTitle))
+	emojis := strings.TrimSpace(tools.GetEmojis(rawTitle, p.Lang))
 
 	var titleParts []string
 	if emojis != "" {
@@ -199,21 +349,104 @@ func buildTelegramHTML(p typesPkg.MainStruct) string {
 		b.WriteString("</b>")
 	}
 
+	var tags []string
+	for _, t := range []string{tools.Hashtags(p.Topics), tools.Cashtags(p.Entities)} {
+		if t != "" {
+			tags = append(tags, t)
+		}
+	}
+	if len(tags) > 0 {
+		b.WriteString("\n")
+		b.WriteString(html.EscapeString(strings.Join(tags, " ")))
+	}
+
+	if also := buildCoverageHTML(p.AlsoCoveredBy); also != "" {
+		b.WriteString("\n\n")
+		b.WriteString(also)
+	}
+
 	return strings.TrimSpace(b.String())
 }
 
-func buildInlineKeyboard(p typesPkg.MainStruct, channelID string) (string, error) {
-	link := strings.TrimSpace(p.Link)
+// buildCoverageHTML renders "Also: Slashdot, Hacker News" with each outlet
+// linked to its own article; one entry per outlet.
+func buildCoverageHTML(coverage []typesPkg.Coverage) string {
+	seen := make(map[string]bool, len(coverage))
+	var parts []string
+	for _, c := range coverage {
+		header := strings.TrimSuffix(strings.TrimSpace(c.Header), ":")
+		if header == "" || seen[header] {
+			continue
+		}
+		seen[header] = true
+
+		if link := strings.TrimSpace(c.Link); link != "" {
+			parts = append(parts, `<a href="`+html.EscapeString(link)+`">`+html.EscapeString(header)+"</a>")
+		} else {
+			parts = append(parts, html.EscapeString(header))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "<i>Also: " + strings.Join(parts, ", ") + "</i>"
+}
+
+func buildDigestHTML(posts []typesPkg.MainStruct, title string, maxLen int) (chunks []string, counts []int) {
+	head := "<b>" + html.EscapeString(strings.TrimSpace(title)) + "</b>"
+
+	var b strings.Builder
+	b.WriteString(head)
+	n := 0 // articles in b
+
+	for _, p := range posts {
+		var line strings.Builder
+		line.WriteString("\n• ")
+		if emojis := strings.TrimSpace(tools.GetEmojis(p.Title, p.Lang)); emojis != "" {
+			line.WriteString(emojis + " ")
+		}
+		if header := strings.TrimSpace(p.Header); header != "" {
+			line.WriteString(html.EscapeString(strings.TrimSuffix(header, ":")) + ": ")
+		}
+		titleText := html.EscapeString(strings.TrimSpace(p.Title))
+		if link := strings.TrimSpace(p.Link); link != "" {
+			line.WriteString(`<a href="` + html.EscapeString(link) + `">` + titleText + "</a>")
+		} else {
+			line.WriteString(titleText)
+		}
+
+		if b.Len() > len(head) && len([]rune(b.String()))+len([]rune(line.String())) > maxLen {
+			chunks, counts = append(chunks, b.String()), append(counts, n)
+			b.Reset()
+			b.WriteString(head)
+			n = 0
+		}
+		b.WriteString(line.String())
+		n++
+	}
+	chunks, counts = append(chunks, b.String()), append(counts, n)
+
+	return chunks, counts
+}
+
+// BoostURL returns the t.me boost deep link for a channel given as
+// "@username", "-100<id>" or a bare numeric ID; empty if it can't be built.
+func BoostURL(channelID string) string {
 	ch := strings.TrimSpace(channelID)
 
-	var boostURL string
 	if after, ok := strings.CutPrefix(ch, "@"); ok {
-		boostURL = "https://t.me/" + after + "?boost"
+		return "https://t.me/" + after + "?boost"
 	} else if strings.HasPrefix(ch, "-100") && len(ch) > 4 {
-		boostURL = "https://t.me/c/" + ch[4:] + "?boost"
+		return "https://t.me/c/" + ch[4:] + "?boost"
 	} else if _, err := strconv.ParseInt(ch, 10, 64); err == nil {
-		boostURL = "https://t.me/c/" + ch + "?boost"
+		return "https://t.me/c/" + ch + "?boost"
 	}
+	return ""
+}
+
+func buildInlineKeyboard(p typesPkg.MainStruct, channelID string) (string, error) {
+	link := strings.TrimSpace(p.Link)
+	boostURL := BoostURL(channelID)
 
 	type btn struct {
 		Text         string `json:"text"`
@@ -224,16 +457,20 @@ func buildInlineKeyboard(p typesPkg.MainStruct, channelID string) (string, error
 		InlineKeyboard [][]btn `json:"inline_keyboard"`
 	}
 
-	row := make([]btn, 0, 2)
+	row := make([]btn, 0, 3)
 	if boostURL != "" {
 		row = append(row, btn{Text: "⚡️ Boost", URL: boostURL})
 	} else {
 		// Fallback: still sends a callback to bot if deep link couldn't be built
-		row = append(row, btn{Text: "⚡️ Boost", CallbackData: ensureMaxBytes("boost:"+p.GUID, 64)})
+		row = append(row, btn{Text: "⚡️ Boost", CallbackData: BoostCallbackPrefix + BoostID(p.GUID)})
 	}
 	if link != "" {
 		row = append(row, btn{Text: "🔗 Read", URL: link})
 	}
+	// Ask/Show HN posts link to the thread already
+	if comments := strings.TrimSpace(p.CommentsLink); comments != "" && comments != link {
+		row = append(row, btn{Text: "💬 Comments", URL: comments})
+	}
 
 	m := markup{InlineKeyboard: [][]btn{row}}
 	b, err := json.Marshal(m)
@@ -250,15 +487,3 @@ func ensureMaxLen(s string, max int) string {
 	}
 	return string(r[:max-1]) + "…"
 }
-
-// Telegram limits callback_data to 1–64 bytes; trim if needed.
-func ensureMaxBytes(s string, max int) string {
-	if len(s) <= max {
-		return s
-	}
-	// naive byte trim with ellipsis; safe since ASCII expected for GUID/prefix
-	if max <= 1 {
-		return s[:max]
-	}
-	return s[:max-1] + "…"
-}
@@ -0,0 +1,42 @@
+package telegram
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"coreheadlines/typesPkg"
+)
+
+func TestBoostCallbackDataFits(t *testing.T) {
+	p := typesPkg.MainStruct{
+		GUID: "Hacker News|https://example.com/" + strings.Repeat("a-very-long-path/", 8) + "?utm_source=feed",
+		Link: "https://example.com/",
+	}
+	markup, err := buildInlineKeyboard(p, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m struct {
+		InlineKeyboard [][]struct {
+			CallbackData string `json:"callback_data"`
+		} `json:"inline_keyboard"`
+	}
+	if err := json.Unmarshal([]byte(markup), &m); err != nil {
+		t.Fatal(err)
+	}
+	data := m.InlineKeyboard[0][0].CallbackData
+	if len(data) == 0 || len(data) > 64 {
+		t.Fatalf("callback_data %q is %d bytes, want 1-64", data, len(data))
+	}
+	if want := BoostCallbackPrefix + BoostID(p.GUID); data != want {
+		t.Errorf("callback_data = %q, want %q", data, want)
+	}
+	if id := strings.TrimPrefix(data, BoostCallbackPrefix); !IsBoostID(id) {
+		t.Errorf("IsBoostID(%q) = false", id)
+	}
+	if IsBoostID(p.GUID) {
+		t.Errorf("IsBoostID(GUID) = true")
+	}
+}
@@ -0,0 +1,127 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Subset of the Bot API update objects the interactive bot cares about.
+type Update struct {
+	UpdateID      int64          `json:"update_id"`
+	Message       *Message       `json:"message,omitempty"`
+	CallbackQuery *CallbackQuery `json:"callback_query,omitempty"`
+}
+
+type CallbackQuery struct {
+	ID      string   `json:"id"`
+	From    User     `json:"from"`
+	Message *Message `json:"message,omitempty"`
+	Data    string   `json:"data,omitempty"`
+}
+
+type Message struct {
+	MessageID int64  `json:"message_id"`
+	From      *User  `json:"from,omitempty"`
+	Chat      Chat   `json:"chat"`
+	Text      string `json:"text,omitempty"`
+}
+
+type User struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"first_name"`
+	Username  string `json:"username,omitempty"`
+}
+
+type Chat struct {
+	ID       int64  `json:"id"`
+	Type     string `json:"type"` // "private", "group", "supergroup", "channel"
+	Title    string `json:"title,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+type updatesResponse struct {
+	OK          bool     `json:"ok"`
+	Result      []Update `json:"result"`
+	Description string   `json:"description"`
+}
+
+// GetUpdates long-polls for new updates (local development; in production
+// Telegram pushes the same objects to the webhook).
+func GetUpdates(ctx context.Context, botToken string, offset int64, timeout time.Duration) ([]Update, error) {
+	form := url.Values{}
+	form.Set("offset", strconv.FormatInt(offset, 10))
+	form.Set("timeout", strconv.Itoa(int(timeout.Seconds())))
+	form.Set("allowed_updates", `["message","callback_query"]`)
+
+	endpoint := fmt.Sprintf("https://api.telegram.org/bot%s/getUpdates?%s", botToken, form.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	client := &http.Client{Timeout: timeout + 10*time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("getUpdates failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read getUpdates body: %w", err)
+	}
+
+	var out updatesResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode getUpdates: %w", err)
+	}
+	if !out.OK {
+		return nil, fmt.Errorf("getUpdates status %d: %s", resp.StatusCode, out.Description)
+	}
+
+	return out.Result, nil
+}
+
+// SendText sends a plain HTML reply to a chat.
+func SendText(ctx context.Context, botToken string, chatID int64, text string) error {
+	endpoint := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", botToken)
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	form := url.Values{}
+	form.Set("chat_id", strconv.FormatInt(chatID, 10))
+	form.Set("text", ensureMaxLen(text, telegramMaxLen))
+	form.Set("parse_mode", "HTML")
+	form.Set("link_preview_options", `{"is_disabled":true}`)
+
+	_, err := postWithRetry(ctx, client, endpoint, form, "reply")
+	return err
+}
+
+// AnswerCallback stops the button spinner. A non-empty text is shown as a
+// toast (or an alert when showAlert is set); link may only point at a
+// t.me/<bot>?start=... deep link, as the Bot API requires.
+func AnswerCallback(ctx context.Context, botToken, callbackID, text string, showAlert bool, link string) error {
+	endpoint := fmt.Sprintf("https://api.telegram.org/bot%s/answerCallbackQuery", botToken)
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	form := url.Values{}
+	form.Set("callback_query_id", callbackID)
+	if text != "" {
+		form.Set("text", ensureMaxLen(text, 200))
+	}
+	if showAlert {
+		form.Set("show_alert", "true")
+	}
+	if link != "" {
+		form.Set("url", link)
+	}
+
+	_, err := postWithRetry(ctx, client, endpoint, form, "callback:"+callbackID)
+	return err
+}
@@ -0,0 +1,183 @@
+package tools
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// CorpusCase is one line of a golden corpus, a JSON Lines file of titles
+// and the emojis a reviewer expects for them, in any order:
+//
+//	{"title": "Oil jumps as OPEC+ cuts output", "emojis": ["🛢️"]}
+//	{"title": "Alemania aprueba el presupuesto", "lang": "es", "emojis": ["🇩🇪"]}
+type CorpusCase struct {
+	Title  string   `json:"title"`
+	Lang   string   `json:"lang,omitempty"`
+	Emojis []string `json:"emojis"`
+}
+
+// ReadCorpus reads a corpus, skipping blank lines.
+func ReadCorpus(r io.Reader) ([]CorpusCase, error) {
+	var cases []CorpusCase
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+
+		var c CorpusCase
+		dec := json.NewDecoder(strings.NewReader(line))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&c); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if strings.TrimSpace(c.Title) == "" {
+			return nil, fmt.Errorf("line %d: no title", n)
+		}
+		cases = append(cases, c)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return cases, nil
+}
+
+// WriteCorpus writes cases one per line, the format ReadCorpus reads.
+func WriteCorpus(w io.Writer, cases []CorpusCase) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, c := range cases {
+		if err := enc.Encode(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CaseResult is what GetEmojis gave for a corpus case.
+type CaseResult struct {
+	Case    CorpusCase
+	Got     []string
+	Missing []string // expected but not given
+	Extra   []string // given but not expected
+}
+
+func (r CaseResult) OK() bool {
+	return len(r.Missing) == 0 && len(r.Extra) == 0
+}
+
+// Summary is the output with what is wrong about it: "🇺🇸💼 (missing 📈, extra 💼)".
+func (r CaseResult) Summary() string {
+	s := strings.Join(r.Got, "")
+	if s == "" {
+		s = "none"
+	}
+	var notes []string
+	if len(r.Missing) > 0 {
+		notes = append(notes, "missing "+strings.Join(r.Missing, " "))
+	}
+	if len(r.Extra) > 0 {
+		notes = append(notes, "extra "+strings.Join(r.Extra, " "))
+	}
+	if len(notes) > 0 {
+		s += " (" + strings.Join(notes, ", ") + ")"
+	}
+	return s
+}
+
+// CorpusReport scores the current dictionaries and EmojiConfig on a corpus.
+// Every expected emoji given is a true positive, every other one given a
+// false positive and every expected one not given a false negative.
+type CorpusReport struct {
+	Results  []CaseResult
+	TruePos  int
+	FalsePos int
+	FalseNeg int
+}
+
+// RunCorpus runs GetEmojis over every case.
+func RunCorpus(cases []CorpusCase) CorpusReport {
+	outputs := make([][]string, len(cases))
+	for i, c := range cases {
+		outputs[i] = pickEmojis(c.Title, c.Lang)
+	}
+	return ScoreCorpus(cases, outputs)
+}
+
+// ScoreCorpus scores outputs[i] as the emojis given for cases[i], such as
+// those of an earlier run saved with WriteCorpus.
+func ScoreCorpus(cases []CorpusCase, outputs [][]string) CorpusReport {
+	var rep CorpusReport
+	for i, c := range cases {
+		r := CaseResult{Case: c, Got: outputs[i]}
+		for _, e := range c.Emojis {
+			if !slices.Contains(r.Got, e) {
+				r.Missing = append(r.Missing, e)
+			}
+		}
+		for _, e := range r.Got {
+			if !slices.Contains(c.Emojis, e) {
+				r.Extra = append(r.Extra, e)
+			}
+		}
+		rep.TruePos += len(r.Got) - len(r.Extra)
+		rep.FalsePos += len(r.Extra)
+		rep.FalseNeg += len(r.Missing)
+		rep.Results = append(rep.Results, r)
+	}
+	return rep
+}
+
+// Precision is the share of emojis given that were expected.
+func (r CorpusReport) Precision() float64 {
+	if r.TruePos+r.FalsePos == 0 {
+		return 1
+	}
+	return float64(r.TruePos) / float64(r.TruePos+r.FalsePos)
+}
+
+// Recall is the share of expected emojis that were given.
+func (r CorpusReport) Recall() float64 {
+	if r.TruePos+r.FalseNeg == 0 {
+		return 1
+	}
+	return float64(r.TruePos) / float64(r.TruePos+r.FalseNeg)
+}
+
+// Passed counts the cases that got exactly the expected emojis.
+func (r CorpusReport) Passed() int {
+	n := 0
+	for _, res := range r.Results {
+		if res.OK() {
+			n++
+		}
+	}
+	return n
+}
+
+func (r CorpusReport) String() string {
+	return fmt.Sprintf("%d/%d titles exact, precision %.3f, recall %.3f", r.Passed(), len(r.Results), r.Precision(), r.Recall())
+}
+
+// CorpusChange is a case whose output differs between two runs.
+type CorpusChange struct {
+	Before, After CaseResult
+}
+
+// CompareCorpus lists the cases whose output changed from before to after,
+// two runs over the same corpus.
+func CompareCorpus(before, after CorpusReport) []CorpusChange {
+	var changes []CorpusChange
+	for i := range min(len(before.Results), len(after.Results)) {
+		b, a := before.Results[i], after.Results[i]
+		if !slices.Equal(b.Got, a.Got) {
+			changes = append(changes, CorpusChange{Before: b, After: a})
+		}
+	}
+	return changes
+}
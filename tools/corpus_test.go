@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"bufio"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+)
+
+// passingFile lists the corpus titles that get exactly their expected
+// emojis, one per line. A change may add titles to it but must not break
+// any; run the test with -update to rewrite it after an improvement.
+const passingFile = "testdata/emoji_corpus_passing.txt"
+
+var update = flag.Bool("update", false, "rewrite "+passingFile)
+
+// The aggregate scores on the golden corpus, a second check on the cases
+// that are not exact yet. Raise them when a change does better.
+const (
+	corpusMinPrecision = 0.770
+	corpusMinRecall    = 0.952
+)
+
+func TestCorpus(t *testing.T) {
+	f, err := os.Open("testdata/emoji_corpus.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	cases, err := ReadCorpus(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rep := RunCorpus(cases)
+	t.Log(rep)
+
+	if *update {
+		var passing []string
+		for _, r := range rep.Results {
+			if r.OK() {
+				passing = append(passing, r.Case.Title)
+			}
+		}
+		if err := os.WriteFile(passingFile, []byte(strings.Join(passing, "\n")+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	passing, err := readPassing()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range rep.Results {
+		if passing[r.Case.Title] && !r.OK() {
+			t.Errorf("regressed: %s\n  want %s\n  got  %s", r.Case.Title, strings.Join(r.Case.Emojis, ""), r.Summary())
+		}
+		delete(passing, r.Case.Title)
+	}
+	for title := range passing {
+		t.Errorf("%s lists %q, which is not in the corpus", passingFile, title)
+	}
+
+	if rep.Precision() < corpusMinPrecision || rep.Recall() < corpusMinRecall {
+		t.Errorf("corpus: %v, want precision at least %.3f and recall at least %.3f",
+			rep, corpusMinPrecision, corpusMinRecall)
+	}
+}
+
+func readPassing() (map[string]bool, error) {
+	f, err := os.Open(passingFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	passing := make(map[string]bool)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if title := strings.TrimSpace(sc.Text()); title != "" {
+			passing[title] = true
+		}
+	}
+	return passing, sc.Err()
+}
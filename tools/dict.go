@@ -0,0 +1,552 @@
+package tools
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"coreheadlines/typesPkg"
+)
+
+// The keyword dictionaries ship embedded; set EMOJI_DICT_DIR (see
+// LoadDictionaries) to swap in edited copies without a rebuild.
+//
+//	countries.json  {"US": ["united states", "usa", ...], ...}  ISO 3166-1 alpha-2 (or EU) -> terms
+//	emojis.json     {"🛢️": ["oil", "crude oil", ...], ...}      emoji -> terms
+//	rules.json      {"Dow": {"excludes": ["dow chemical"]}, ...} term -> context rule
+//	weights.json    {"📈": 0.7, "🇺🇸": 1.2, ...}                 emoji or flag -> relevance weight (default 1)
+//	entities.json   {"nvidia": {"type": "company", ...}, ...}   entity ID -> person, company or index
+//
+// Other languages add their own terms in a directory named by the ISO 639-1
+// code (see translations.go), e.g. es/countries.json and es/emojis.json.
+//
+// Terms containing an uppercase letter are acronyms or names and only match
+// with that exact casing ("US", "Xi"); all-lowercase terms ignore case and
+// are base forms that also match their inflections ("trap" finds "trapped",
+// see Stem), so list only one form per key. In es/ and pt/ they match their
+// plurals ("incêndio" finds "incêndios"); see stemmerFor.
+//
+//go:embed data/*.json data/*/*.json
+var embeddedDicts embed.FS
+
+const (
+	CountriesFile = "countries.json"
+	EmojisFile    = "emojis.json"
+	RulesFile     = "rules.json"
+	WeightsFile   = "weights.json"
+	EntitiesFile  = "entities.json"
+)
+
+// ContextRule constrains an ambiguous term by the rest of the title: at
+// least one Requires phrase must appear (when any are listed) and no
+// Excludes phrase may. Phrases match whole words, ignoring case.
+type ContextRule struct {
+	Requires []string `json:"requires,omitempty"`
+	Excludes []string `json:"excludes,omitempty"`
+}
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Problem is one validation finding in a dictionary file.
+type Problem struct {
+	Severity Severity
+	File     string
+	Key      string // ISO code or emoji
+	Term     string
+	Msg      string
+}
+
+func (p Problem) String() string {
+	s := fmt.Sprintf("%s: %s: %q", p.File, p.Severity, p.Key)
+	if p.Term != "" {
+		s += fmt.Sprintf(" term %q", p.Term)
+	}
+	return s + ": " + p.Msg
+}
+
+// Dictionaries are the term lookups GetEmojis matches titles against.
+type Dictionaries struct {
+	Countries map[string]string      // term -> ISO code
+	Emoji     map[string]string      // term -> emoji
+	Rules     map[string]ContextRule // term -> context rule
+	Weights   map[string]float64     // emoji or flag -> weight
+
+	Entities    map[string]typesPkg.Entity // entity ID -> entity
+	EntityTerms map[string]string          // term -> entity ID
+
+	Translations map[string]*Translation // language code -> its own terms
+}
+
+// ReadDictionaries reads and validates the dictionary files in dir and its
+// language subdirectories; files missing from dir (or every file, when dir
+// is "") come from the embedded defaults. Problems are returned even when
+// err is nil; Dictionaries is nil if any of them is an error.
+func ReadDictionaries(dir string) (*Dictionaries, []Problem, error) {
+	countries, err := readDictFile(dir, CountriesFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	emojis, err := readDictFile(dir, EmojisFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	rules, err := readDictFile(dir, RulesFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	weights, err := readDictFile(dir, WeightsFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	entities, err := readDictFile(dir, EntitiesFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, problems := ValidateDictionaries(countries, emojis, rules, weights, entities)
+	if hasErrors(problems) {
+		return nil, problems, nil
+	}
+
+	var tp []Problem
+	d.Translations, tp, err = readTranslations(dir, d)
+	if err != nil {
+		return nil, nil, err
+	}
+	problems = append(problems, tp...)
+	if hasErrors(tp) {
+		return nil, problems, nil
+	}
+	return d, problems, nil
+}
+
+// LoadDictionaries reads dir like ReadDictionaries and, if the files are
+// valid, makes them the ones GetEmojis uses. Call it at startup only.
+func LoadDictionaries(dir string) ([]Problem, error) {
+	d, problems, err := ReadDictionaries(dir)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return problems, errors.New("invalid emoji dictionaries")
+	}
+	installDictionaries(d)
+	return problems, nil
+}
+
+func hasErrors(problems []Problem) bool {
+	for _, p := range problems {
+		if p.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func readDictFile(dir, name string) ([]byte, error) {
+	b, err := readOptionalDictFile(dir, name)
+	if err == nil && b == nil {
+		return nil, fmt.Errorf("read %s: %w", name, fs.ErrNotExist)
+	}
+	return b, err
+}
+
+// readOptionalDictFile is readDictFile for files a language may leave out:
+// it returns nil when neither dir nor the embedded data has name.
+func readOptionalDictFile(dir, name string) ([]byte, error) {
+	if dir != "" {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err == nil {
+			return b, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("read %s: %w", name, err)
+		}
+	}
+	b, err := embeddedDicts.ReadFile("data/" + name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return b, err
+}
+
+// ValidateDictionaries parses the files, checking for duplicate keys, terms
+// mapped to more than one key, unknown country codes, malformed emoji, and
+// rules or weights for terms and emoji that do not exist. The returned
+// Dictionaries holds whatever parsed.
+func ValidateDictionaries(countries, emojis, rules, weights, entities []byte) (*Dictionaries, []Problem) {
+	var problems []Problem
+	d := &Dictionaries{}
+
+	var cp, ep []Problem
+	d.Countries, cp = parseDictFile(CountriesFile, countries, Stem, checkCountryCode)
+	d.Emoji, ep = parseDictFile(EmojisFile, emojis, Stem, checkEmoji)
+	problems = append(append(problems, cp...), ep...)
+
+	var rp []Problem
+	d.Rules, rp = parseRulesFile(rules, func(term string) bool {
+		_, inCountries := d.Countries[term]
+		_, inEmoji := d.Emoji[term]
+		return inCountries || inEmoji
+	})
+	problems = append(problems, rp...)
+
+	used := make(map[string]bool)
+	for _, e := range d.Emoji {
+		used[e] = true
+	}
+	for _, code := range d.Countries {
+		used[countryCodeToFlag(code)] = true
+	}
+	var wp []Problem
+	d.Weights, wp = parseWeightsFile(weights, func(emoji string) bool { return used[emoji] })
+	problems = append(problems, wp...)
+
+	var np []Problem
+	d.Entities, d.EntityTerms, np = parseEntitiesFile(entities)
+	problems = append(problems, np...)
+
+	// Same term in both files is allowed (flag and emoji) but worth knowing
+	terms := make([]string, 0, len(d.Emoji))
+	for term := range d.Emoji {
+		if _, ok := d.Countries[term]; ok {
+			terms = append(terms, term)
+		}
+	}
+	sort.Strings(terms)
+	for _, term := range terms {
+		problems = append(problems, Problem{
+			Severity: SeverityWarning, File: EmojisFile, Key: d.Emoji[term], Term: term,
+			Msg: "also a country term for " + d.Countries[term],
+		})
+	}
+
+	return d, problems
+}
+
+// parseDictFile walks the JSON object token by token, since decoding into
+// a map would silently keep only the last of two duplicate keys. Terms are
+// checked for inflections of each other under stem.
+func parseDictFile(name string, data []byte, stem func(string) string, checkKey func(string) string) (map[string]string, []Problem) {
+	var problems []Problem
+	add := func(sev Severity, key, term, msg string) {
+		problems = append(problems, Problem{Severity: sev, File: name, Key: key, Term: term, Msg: msg})
+	}
+	fail := func(err error) (map[string]string, []Problem) {
+		add(SeverityError, "", "", "malformed JSON: "+err.Error())
+		return nil, problems
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	if tok, err := dec.Token(); err != nil {
+		return fail(err)
+	} else if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fail(errors.New("top level must be an object"))
+	}
+
+	out := make(map[string]string)
+	seenKeys := make(map[string]bool)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fail(err)
+		}
+		key := tok.(string) // object keys are always strings
+
+		var terms []string
+		if err := dec.Decode(&terms); err != nil {
+			return fail(fmt.Errorf("value of %q: %w", key, err))
+		}
+
+		if seenKeys[key] {
+			add(SeverityError, key, "", "duplicate key")
+		}
+		seenKeys[key] = true
+		if msg := checkKey(key); msg != "" {
+			add(SeverityError, key, "", msg)
+		}
+		if len(terms) == 0 {
+			add(SeverityWarning, key, "", "no terms")
+		}
+
+		for _, term := range terms {
+			switch prev, ok := out[term]; {
+			case strings.TrimSpace(term) == "":
+				add(SeverityError, key, term, "empty term")
+			case term != strings.TrimSpace(term):
+				add(SeverityError, key, term, "leading or trailing space")
+			case ok && prev == key:
+				add(SeverityWarning, key, term, "repeated term")
+			case ok:
+				add(SeverityError, key, term, "conflicting mapping, also listed under "+prev)
+			default:
+				out[term] = key
+			}
+		}
+	}
+
+	// "U.S." next to "u.s." never adds a match: the lowercase one ignores case
+	for term, key := range out {
+		if lower := strings.ToLower(term); lower != term && out[lower] == key {
+			add(SeverityWarning, key, term, "redundant, "+lower+" already matches any casing")
+		}
+	}
+
+	// "traps" next to "trap" never adds a match either: both match on stems.
+	// Across keys the inflections stay, as the exact form picks the emoji.
+	for _, group := range inflectionGroups(out, stem) {
+		base := group[0]
+		shared := false
+		for _, term := range group[1:] {
+			shared = shared || out[term] != out[base]
+		}
+		for _, term := range group[1:] {
+			switch {
+			case !shared:
+				add(SeverityWarning, out[term], term, "redundant inflection of "+base+", run emojitool stem")
+			case out[term] != out[base]:
+				add(SeverityWarning, out[term], term, "matches the same words as "+base+" under "+out[base])
+			}
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fail(err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return fail(errors.New("trailing data after the object"))
+	}
+	return out, problems
+}
+
+func parseRulesFile(data []byte, known func(term string) bool) (map[string]ContextRule, []Problem) {
+	var problems []Problem
+	add := func(sev Severity, term, msg string) {
+		problems = append(problems, Problem{Severity: sev, File: RulesFile, Term: term, Msg: msg})
+	}
+	fail := func(err error) (map[string]ContextRule, []Problem) {
+		add(SeverityError, "", "malformed JSON: "+err.Error())
+		return nil, problems
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.DisallowUnknownFields()
+	if tok, err := dec.Token(); err != nil {
+		return fail(err)
+	} else if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fail(errors.New("top level must be an object"))
+	}
+
+	out := make(map[string]ContextRule)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fail(err)
+		}
+		term := tok.(string)
+
+		var rule ContextRule
+		if err := dec.Decode(&rule); err != nil {
+			return fail(fmt.Errorf("rule for %q: %w", term, err))
+		}
+
+		if _, dup := out[term]; dup {
+			add(SeverityError, term, "duplicate rule")
+		}
+		if !known(term) {
+			// Most likely a casing mismatch ("dow" for "Dow")
+			add(SeverityError, term, "rule for a term that is in no dictionary")
+		}
+		if len(rule.Requires) == 0 && len(rule.Excludes) == 0 {
+			add(SeverityWarning, term, "empty rule")
+		}
+		for _, phrase := range append(append([]string(nil), rule.Requires...), rule.Excludes...) {
+			if strings.TrimSpace(phrase) == "" {
+				add(SeverityError, term, "empty context phrase")
+			}
+		}
+
+		for i := range rule.Requires {
+			rule.Requires[i] = strings.ToLower(strings.TrimSpace(rule.Requires[i]))
+		}
+		for i := range rule.Excludes {
+			rule.Excludes[i] = strings.ToLower(strings.TrimSpace(rule.Excludes[i]))
+		}
+		out[term] = rule
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fail(err)
+	}
+	return out, problems
+}
+
+func parseWeightsFile(data []byte, used func(emoji string) bool) (map[string]float64, []Problem) {
+	var problems []Problem
+	add := func(sev Severity, key, msg string) {
+		problems = append(problems, Problem{Severity: sev, File: WeightsFile, Key: key, Msg: msg})
+	}
+	fail := func(err error) (map[string]float64, []Problem) {
+		add(SeverityError, "", "malformed JSON: "+err.Error())
+		return nil, problems
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	if tok, err := dec.Token(); err != nil {
+		return fail(err)
+	} else if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fail(errors.New("top level must be an object"))
+	}
+
+	out := make(map[string]float64)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fail(err)
+		}
+		key := tok.(string)
+
+		var w float64
+		if err := dec.Decode(&w); err != nil {
+			return fail(fmt.Errorf("weight of %q: %w", key, err))
+		}
+
+		if _, dup := out[key]; dup {
+			add(SeverityError, key, "duplicate weight")
+		}
+		if msg := checkEmoji(key); msg != "" {
+			add(SeverityError, key, msg)
+		} else if !used(key) {
+			add(SeverityWarning, key, "weight for an emoji no term produces")
+		}
+		if w <= 0 {
+			add(SeverityError, key, "weight must be positive")
+		}
+		out[key] = w
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fail(err)
+	}
+	return out, problems
+}
+
+func isCountryCode(code string) bool {
+	return code == "EU" || isoCountries[code]
+}
+
+func checkCountryCode(code string) string {
+	if !isCountryCode(code) {
+		return "unknown ISO 3166-1 alpha-2 code"
+	}
+	return ""
+}
+
+// checkEmoji accepts the shapes of fully-qualified emoji: pictographs with
+// optional VS16 and skin tone, ZWJ sequences of those, keycaps, flag pairs
+// and subdivision tag sequences. It returns a reason when s is none of them.
+func checkEmoji(s string) string {
+	if s == "" {
+		return "empty emoji"
+	}
+	if !utf8.ValidString(s) {
+		return "invalid UTF-8"
+	}
+
+	rs := []rune(s)
+	ri := 0
+	for i, r := range rs {
+		switch {
+		case r == 0x200D: // ZWJ joins two pictographs
+			if i == 0 || i == len(rs)-1 || rs[i-1] == 0x200D {
+				return "misplaced zero width joiner"
+			}
+		case r == 0xFE0F: // VS16
+			if i == 0 {
+				return "variation selector without a base"
+			}
+		case r >= 0x1F3FB && r <= 0x1F3FF: // skin tone
+			if i == 0 || !isPictograph(rs[i-1]) {
+				return "skin tone modifier without a base"
+			}
+		case r == 0x20E3: // keycap
+			if i == 0 || !isKeycapBase(rs[i-1]) && !(rs[i-1] == 0xFE0F && i > 1 && isKeycapBase(rs[i-2])) {
+				return "keycap without a digit, # or *"
+			}
+		case r >= 0x1F1E6 && r <= 0x1F1FF: // regional indicator
+			ri++
+		case r >= 0xE0020 && r <= 0xE007F: // tag sequence (subdivision flags)
+			if i == 0 {
+				return "tag without a base"
+			}
+		case isKeycapBase(r):
+			if i+1 >= len(rs) || rs[i+1] != 0x20E3 && rs[i+1] != 0xFE0F {
+				return fmt.Sprintf("plain character %q", r)
+			}
+		case !isPictograph(r):
+			return fmt.Sprintf("U+%04X is not an emoji character", r)
+		}
+	}
+	if ri%2 != 0 {
+		return "unpaired regional indicator"
+	}
+	return ""
+}
+
+func isKeycapBase(r rune) bool {
+	return r >= '0' && r <= '9' || r == '#' || r == '*'
+}
+
+// isPictograph approximates Extended_Pictographic with the blocks emoji
+// actually come from.
+func isPictograph(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF, // Mahjong .. Symbols and Pictographs Extended-A
+		r >= 0x2600 && r <= 0x27BF, // Misc Symbols, Dingbats
+		r >= 0x2300 && r <= 0x23FF, // Misc Technical
+		r >= 0x2B00 && r <= 0x2BFF, // Misc Symbols and Arrows
+		r >= 0x2190 && r <= 0x21FF, // Arrows
+		r >= 0x25A0 && r <= 0x25FF, // Geometric Shapes
+		r >= 0x2900 && r <= 0x297F: // Supplemental Arrows-B
+		return true
+	}
+	switch r {
+	case 0x00A9, 0x00AE, 0x203C, 0x2049, 0x2122, 0x2139, 0x24C2, 0x3030, 0x303D, 0x3297, 0x3299:
+		return true
+	}
+	return false
+}
+
+var isoCountries = func() map[string]bool {
+	m := make(map[string]bool)
+	for _, code := range strings.Fields(isoCountryCodes) {
+		m[code] = true
+	}
+	return m
+}()
+
+// ISO 3166-1 alpha-2 officially assigned codes.
+const isoCountryCodes = "" +
+	"AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ " +
+	"BL BM BN BO BQ BR BS BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR " +
+	"CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR " +
+	"GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU " +
+	"ID IE IL IM IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN KP KR KW KY KZ " +
+	"LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ " +
+	"MR MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF " +
+	"PG PH PK PL PM PN PR PS PT PW PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI " +
+	"SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR " +
+	"TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW"
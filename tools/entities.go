@@ -0,0 +1,182 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"coreheadlines/typesPkg"
+)
+
+// Entity types in entities.json.
+const (
+	EntityPerson  = "person"
+	EntityCompany = "company"
+	EntityIndex   = "index"
+)
+
+// entityDef is one entities.json entry, keyed by entity ID:
+//
+//	"nvidia": {"type": "company", "name": "Nvidia", "ticker": "NVDA", "terms": ["nvidia", "NVDA"]}
+//
+// Terms follow the casing rules of the other dictionaries.
+type entityDef struct {
+	Type   string   `json:"type"`
+	Name   string   `json:"name"`
+	Ticker string   `json:"ticker,omitempty"`
+	Terms  []string `json:"terms"`
+}
+
+var (
+	tickerPattern  = regexp.MustCompile(`^[A-Z0-9]{1,6}$`)
+	cashtagPattern = regexp.MustCompile(`\$([A-Z]{1,6})\b`)
+)
+
+func parseEntitiesFile(data []byte) (map[string]typesPkg.Entity, map[string]string, []Problem) {
+	var problems []Problem
+	add := func(sev Severity, key, term, msg string) {
+		problems = append(problems, Problem{Severity: sev, File: EntitiesFile, Key: key, Term: term, Msg: msg})
+	}
+	fail := func(err error) (map[string]typesPkg.Entity, map[string]string, []Problem) {
+		add(SeverityError, "", "", "malformed JSON: "+err.Error())
+		return nil, nil, problems
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.DisallowUnknownFields()
+	if tok, err := dec.Token(); err != nil {
+		return fail(err)
+	} else if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fail(errors.New("top level must be an object"))
+	}
+
+	entities := make(map[string]typesPkg.Entity)
+	terms := make(map[string]string) // term -> entity ID
+	tickers := make(map[string]string)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fail(err)
+		}
+		id := tok.(string)
+
+		var def entityDef
+		if err := dec.Decode(&def); err != nil {
+			return fail(fmt.Errorf("entity %q: %w", id, err))
+		}
+
+		if _, dup := entities[id]; dup {
+			add(SeverityError, id, "", "duplicate entity")
+		}
+		switch def.Type {
+		case EntityPerson, EntityCompany, EntityIndex:
+		default:
+			add(SeverityError, id, "", fmt.Sprintf("unknown type %q (want person, company or index)", def.Type))
+		}
+		if strings.TrimSpace(def.Name) == "" {
+			add(SeverityError, id, "", "no name")
+		}
+		if def.Ticker != "" {
+			if !tickerPattern.MatchString(def.Ticker) {
+				add(SeverityError, id, "", fmt.Sprintf("ticker %q is not 1-6 uppercase letters or digits", def.Ticker))
+			} else if prev, ok := tickers[def.Ticker]; ok {
+				add(SeverityWarning, id, "", "ticker "+def.Ticker+" also used by "+prev)
+			} else {
+				tickers[def.Ticker] = id
+			}
+		}
+		if len(def.Terms) == 0 {
+			add(SeverityWarning, id, "", "no terms, only found by cashtag")
+		}
+
+		for _, term := range def.Terms {
+			switch prev, ok := terms[term]; {
+			case strings.TrimSpace(term) == "":
+				add(SeverityError, id, term, "empty term")
+			case term != strings.TrimSpace(term):
+				add(SeverityError, id, term, "leading or trailing space")
+			case ok && prev == id:
+				add(SeverityWarning, id, term, "repeated term")
+			case ok:
+				add(SeverityError, id, term, "conflicting mapping, also listed under "+prev)
+			default:
+				terms[term] = id
+			}
+		}
+
+		entities[id] = typesPkg.Entity{ID: id, Type: def.Type, Name: def.Name, Ticker: def.Ticker}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fail(err)
+	}
+	return entities, terms, problems
+}
+
+var (
+	entityMatcher  func() *Matcher   // built on first use
+	entityByTicker map[string]string // ticker -> entity ID
+)
+
+func buildEntityIndex(d *Dictionaries) {
+	terms := make([]string, 0, len(d.EntityTerms))
+	for term := range d.EntityTerms {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+	entityMatcher = sync.OnceValue(func() *Matcher { return NewMatcher(terms) })
+
+	entityByTicker = make(map[string]string)
+	for id, e := range d.Entities {
+		if e.Ticker != "" {
+			entityByTicker[e.Ticker] = id
+		}
+	}
+}
+
+// ExtractEntities finds the dictionary's people, companies and indices in a
+// title, in title order and each once. Cashtags ("$NVDA") count as their
+// company even when the ticker is not one of its terms.
+func ExtractEntities(title string) []typesPkg.Entity {
+	type hit struct {
+		id    string
+		start int
+	}
+	var hits []hit
+	for _, m := range SelectLongest(entityMatcher().FindAll(title)) {
+		hits = append(hits, hit{EntityTerms[m.Term], m.Start})
+	}
+	for _, loc := range cashtagPattern.FindAllStringSubmatchIndex(title, -1) {
+		if id, ok := entityByTicker[title[loc[2]:loc[3]]]; ok {
+			hits = append(hits, hit{id, loc[0]})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].start < hits[j].start })
+
+	var out []typesPkg.Entity
+	seen := make(map[string]bool)
+	for _, h := range hits {
+		if !seen[h.id] {
+			seen[h.id] = true
+			out = append(out, Entities[h.id])
+		}
+	}
+	return out
+}
+
+// Cashtags renders the tickers of the companies among entities, "$NVDA
+// $TSLA", which Telegram links to their quotes. Index tickers are left out,
+// as they are not tradable symbols.
+func Cashtags(entities []typesPkg.Entity) string {
+	var tags []string
+	for _, e := range entities {
+		if e.Type == EntityCompany && e.Ticker != "" {
+			tags = append(tags, "$"+e.Ticker)
+		}
+	}
+	return strings.Join(tags, " ")
+}
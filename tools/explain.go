@@ -0,0 +1,139 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"coreheadlines/lang"
+)
+
+// Explanation shows how GetEmojis arrived at its result for a title.
+type Explanation struct {
+	Title      string         `json:"title"`
+	Language   string         `json:"language,omitempty"`
+	Dictionary string         `json:"dictionary"` // the language whose terms were used, over English
+	Options    EmojiOptions   `json:"options"`
+	Matches    []MatchOutcome `json:"matches"`
+	Emojis     string         `json:"emojis"` // what GetEmojis returns
+}
+
+// MatchOutcome is one term found in the title and what became of the emoji
+// or flag it produces. A term listed in both dictionaries appears twice.
+type MatchOutcome struct {
+	Term   string  `json:"term"`
+	Text   string  `json:"text"`  // the title text it matched
+	Start  int     `json:"start"` // byte offsets into the title
+	End    int     `json:"end"`
+	Exact  bool    `json:"exact"` // false when only the stems matched
+	Emoji  string  `json:"emoji"`
+	Flag   bool    `json:"flag"`
+	Score  float64 `json:"score"` // this match's share of the emoji's score
+	Total  float64 `json:"total"` // the emoji's score over all its matches
+	Kept   bool    `json:"kept"`
+	Reason string  `json:"reason"`
+}
+
+// Explain runs GetEmojis under EmojiConfig, recording for every term found
+// in the title whether its emoji made it into the result and why.
+func Explain(title, language string) Explanation {
+	s := selectEmojis(title, language, EmojiConfig)
+	e := Explanation{Title: title, Language: language, Dictionary: s.ts.language, Options: EmojiConfig}
+
+	outcome := func(m Match, o termOutput, kept bool, reason string) MatchOutcome {
+		return MatchOutcome{
+			Term: m.Term, Text: title[m.Start:m.End], Start: m.Start, End: m.End, Exact: m.Exact,
+			Emoji: o.emoji, Flag: o.flag, Kept: kept, Reason: reason,
+		}
+	}
+
+	// Context rules
+	for _, r := range s.rejected {
+		for _, o := range s.ts.outputs(r.Term) {
+			e.Matches = append(e.Matches, outcome(r.Match, o, false, r.reason))
+		}
+	}
+
+	// Overlaps
+	isSelected := make(map[Match]bool, len(s.selected))
+	for _, m := range s.selected {
+		isSelected[m] = true
+	}
+	for _, m := range s.allowed {
+		if isSelected[m] {
+			continue
+		}
+		reason := "overlaps a longer match"
+		for _, sel := range s.selected {
+			if m.Start == sel.Start && m.End == sel.End {
+				reason = fmt.Sprintf("same words as the preferred match %q", sel.Term)
+				break
+			}
+			if m.Start < sel.End && sel.Start < m.End {
+				reason = fmt.Sprintf("overlaps the longer match %q", sel.Term)
+				break
+			}
+		}
+		for _, o := range s.ts.outputs(m.Term) {
+			e.Matches = append(e.Matches, outcome(m, o, false, reason))
+		}
+	}
+
+	// Scoring and the cap
+	rank := make(map[string]int, len(s.ranked))
+	for i, c := range s.ranked {
+		rank[c.emoji] = i + 1
+	}
+	record := func(c candidate, kept bool, reason string) {
+		for _, m := range c.matches {
+			mo := outcome(m, termOutput{emoji: c.emoji, flag: c.flag}, kept, reason)
+			mo.Score = matchScore(m, len(title), emojiWeight(c.emoji))
+			mo.Total = c.score
+			e.Matches = append(e.Matches, mo)
+		}
+	}
+	for i, c := range s.picked {
+		reason := fmt.Sprintf("slot %d of %d, score rank %d", i+1, EmojiConfig.Max, rank[c.emoji])
+		if rank[c.emoji] > EmojiConfig.Max {
+			reason += ", moved up as flags go first"
+		}
+		record(c, true, reason)
+	}
+	for _, c := range s.dropped {
+		reason := fmt.Sprintf("over the cap of %d, score rank %d", EmojiConfig.Max, rank[c.emoji])
+		if rank[c.emoji] <= EmojiConfig.Max {
+			reason += ", pushed out as flags go first"
+		}
+		record(c, false, reason)
+	}
+
+	sort.SliceStable(e.Matches, func(i, j int) bool { return e.Matches[i].Start < e.Matches[j].Start })
+
+	e.Emojis = strings.Join(s.emojis(), "")
+	return e
+}
+
+func (e Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", e.Title)
+	if !lang.IsEnglish(e.Dictionary) {
+		fmt.Fprintf(&b, "  dictionary: %s, then English\n", e.Dictionary)
+	}
+	for _, m := range e.Matches {
+		mark := "-"
+		if m.Kept {
+			mark = "+"
+		}
+		how := "exact"
+		if !m.Exact {
+			how = "stem"
+		}
+		fmt.Fprintf(&b, "  %s %3d-%-3d %-20q %-5s %s", mark, m.Start, m.End, m.Term, how, m.Emoji)
+		if m.Total > 0 {
+			fmt.Fprintf(&b, "  %.2f/%.2f", m.Score, m.Total)
+		}
+		fmt.Fprintf(&b, "  %s\n", m.Reason)
+	}
+	fmt.Fprintf(&b, "  => %s\n", e.Emojis)
+	return b.String()
+}
@@ -0,0 +1,24 @@
+package tools
+
+import (
+	"os"
+	"testing"
+)
+
+func TestExplainAgreesWithGetEmojis(t *testing.T) {
+	f, err := os.Open("testdata/emoji_corpus.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	cases, err := ReadCorpus(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range cases {
+		if got, want := Explain(c.Title, c.Lang).Emojis, GetEmojis(c.Title, c.Lang); got != want {
+			t.Errorf("Explain(%q).Emojis = %q, GetEmojis gives %q", c.Title, got, want)
+		}
+	}
+}
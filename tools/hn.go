@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"coreheadlines/feeds"
+)
+
+// hnrss.org puts the story metadata in the item description:
+//
+//	<p>Comments URL: <a href="https://news.ycombinator.com/item?id=1">…</a></p>
+//	<p>Points: 152</p>
+//	<p># Comments: 87</p>
+var (
+	hnPointsRe   = regexp.MustCompile(`Points:\s*(\d+)`)
+	hnCommentsRe = regexp.MustCompile(`#\s*Comments:\s*(\d+)`)
+	hnThreadRe   = regexp.MustCompile(`Comments URL:\s*<a href="([^"]+)"`)
+)
+
+type hnMeta struct {
+	Points   int
+	Comments int
+	Thread   string
+}
+
+func isHNRSS(feedURL string) bool {
+	u, err := url.Parse(feedURL)
+	return err == nil && strings.EqualFold(u.Hostname(), "hnrss.org")
+}
+
+func parseHNDescription(desc string) hnMeta {
+	var m hnMeta
+	if sm := hnPointsRe.FindStringSubmatch(desc); sm != nil {
+		m.Points, _ = strconv.Atoi(sm[1])
+	}
+	if sm := hnCommentsRe.FindStringSubmatch(desc); sm != nil {
+		m.Comments, _ = strconv.Atoi(sm[1])
+	}
+	if sm := hnThreadRe.FindStringSubmatch(desc); sm != nil {
+		m.Thread = strings.TrimSpace(sm[1])
+	}
+	return m
+}
+
+// hnFeedURL adds the thresholds as hnrss query parameters so the feed only
+// carries qualifying stories.
+func hnFeedURL(feedURL string, opts feeds.HNOptions) string {
+	u, err := url.Parse(feedURL)
+	if err != nil || !isHNRSS(feedURL) {
+		return feedURL
+	}
+	q := u.Query()
+	if opts.MinPoints > 0 {
+		q.Set("points", strconv.Itoa(opts.MinPoints))
+	}
+	if opts.MinComments > 0 {
+		q.Set("comments", strconv.Itoa(opts.MinComments))
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
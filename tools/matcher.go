@@ -0,0 +1,250 @@
+package tools
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"coreheadlines/words"
+)
+
+// Matcher finds dictionary terms in a title in a single pass using
+// Aho-Corasick automata over lowercased runes. Terms match as whole words.
+// Terms with an uppercase letter are names or acronyms and must match the
+// original casing exactly; all-lowercase terms are base forms and are
+// matched word by word on stems, so "trap" also finds "traps" and "trapped".
+type Matcher struct {
+	exact   automaton
+	stemmed automaton
+	stem    func(string) string
+}
+
+type automaton struct {
+	nodes []acNode
+	terms []acTerm
+}
+
+type acNode struct {
+	next map[rune]int32
+	fail int32
+	out  []int32 // terms ending here, including those reached via fail links
+}
+
+type acTerm struct {
+	term          string
+	runes         []rune // the pattern: original casing, or the stemmed term
+	caseSensitive bool
+	wordStart     bool // needs a word boundary before
+	wordEnd       bool // needs a word boundary after
+}
+
+// Match is one occurrence of a term; Start and End are byte offsets into
+// the matched text. Exact is false when the term only matched on stems
+// ("traps" for "trap").
+type Match struct {
+	Term  string
+	Start int
+	End   int
+	Exact bool
+}
+
+// NewMatcher builds a Matcher for English terms.
+func NewMatcher(terms []string) *Matcher {
+	return newMatcher(terms, nil, Stem)
+}
+
+// newMatcher builds a Matcher whose lowercase terms match on stems under
+// stem, as picked by stemmerFor, and whose plain terms only ignore case.
+func newMatcher(terms, plain []string, stem func(string) string) *Matcher {
+	m := &Matcher{exact: newAutomaton(), stemmed: newAutomaton(), stem: stem}
+	for _, term := range terms {
+		if term == "" {
+			continue
+		}
+		if hasUpper(term) {
+			m.exact.add(term, []rune(term), true)
+		} else {
+			m.stemmed.add(term, []rune(stemPhrase(term, stem)), false)
+		}
+	}
+	for _, term := range plain {
+		if term != "" {
+			m.exact.add(term, []rune(term), hasUpper(term))
+		}
+	}
+	m.exact.link()
+	m.stemmed.link()
+	return m
+}
+
+func newAutomaton() automaton {
+	return automaton{nodes: []acNode{{}}}
+}
+
+func (a *automaton) add(term string, rs []rune, caseSensitive bool) {
+	if len(rs) == 0 {
+		return
+	}
+	id := int32(len(a.terms))
+	a.terms = append(a.terms, acTerm{
+		term:          term,
+		runes:         rs,
+		caseSensitive: caseSensitive,
+		wordStart:     words.IsWordRune(rs[0]),
+		wordEnd:       words.IsWordRune(rs[len(rs)-1]),
+	})
+
+	state := int32(0)
+	for _, r := range rs {
+		r = unicode.ToLower(r)
+		nxt, ok := a.nodes[state].next[r]
+		if !ok {
+			nxt = int32(len(a.nodes))
+			a.nodes = append(a.nodes, acNode{})
+			if a.nodes[state].next == nil {
+				a.nodes[state].next = make(map[rune]int32)
+			}
+			a.nodes[state].next[r] = nxt
+		}
+		state = nxt
+	}
+	a.nodes[state].out = append(a.nodes[state].out, id)
+}
+
+// link sets the fail links breadth-first; each node inherits its fail
+// target's outputs.
+func (a *automaton) link() {
+	queue := make([]int32, 0, len(a.nodes))
+	for _, child := range a.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for r, child := range a.nodes[state].next {
+			f := a.nodes[state].fail
+			for {
+				if nxt, ok := a.nodes[f].next[r]; ok {
+					a.nodes[child].fail = nxt
+					break
+				}
+				if f == 0 {
+					a.nodes[child].fail = 0
+					break
+				}
+				f = a.nodes[f].fail
+			}
+			a.nodes[child].out = append(a.nodes[child].out, a.nodes[a.nodes[child].fail].out...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+// FindAll returns every whole-word occurrence of every term, overlapping
+// ones included, in order of end position.
+func (m *Matcher) FindAll(text string) []Match {
+	var exact stemmedText // the text as is, in the same shape
+	for i, r := range text {
+		exact.runes = append(exact.runes, r)
+		exact.starts = append(exact.starts, i)
+		exact.ends = append(exact.ends, i+utf8.RuneLen(r))
+	}
+
+	matches := m.exact.scan(text, exact)
+	if len(m.stemmed.terms) > 0 {
+		matches = append(matches, m.stemmed.scan(text, stemText(text, m.stem))...)
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].End < matches[j].End })
+	return matches
+}
+
+func (a *automaton) scan(text string, t stemmedText) []Match {
+	var matches []Match
+	state := int32(0)
+	for i, r := range t.runes {
+		lr := unicode.ToLower(r)
+		for {
+			if nxt, ok := a.nodes[state].next[lr]; ok {
+				state = nxt
+				break
+			}
+			if state == 0 {
+				break
+			}
+			state = a.nodes[state].fail
+		}
+
+		for _, id := range a.nodes[state].out {
+			at := &a.terms[id]
+			start, end := i+1-len(at.runes), i+1
+			if at.wordStart && start > 0 && words.IsWordRune(t.runes[start-1]) {
+				continue
+			}
+			if at.wordEnd && end < len(t.runes) && words.IsWordRune(t.runes[end]) {
+				continue
+			}
+			if at.caseSensitive && !equalRunes(t.runes[start:end], at.runes) {
+				continue
+			}
+			m := Match{Term: at.term, Start: t.starts[start], End: t.ends[end-1]}
+			m.Exact = at.caseSensitive || strings.EqualFold(text[m.Start:m.End], at.term)
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// SelectLongest keeps the longest matches that do not overlap a longer (or,
+// for equal lengths, earlier) one, returned in text order. Of terms covering
+// the same words, an exact match beats one found on stems, so "stocks"
+// keeps its own emoji even when "stock" has another.
+func SelectLongest(matches []Match) []Match {
+	byLen := append([]Match(nil), matches...)
+	sort.SliceStable(byLen, func(i, j int) bool {
+		a, b := byLen[i], byLen[j]
+		if la, lb := a.End-a.Start, b.End-b.Start; la != lb {
+			return la > lb
+		}
+		if a.Start != b.Start {
+			return a.Start < b.Start
+		}
+		if a.Exact != b.Exact {
+			return a.Exact
+		}
+		// Otherwise the base form: "move" for "moving" over "moves"
+		if la, lb := utf8.RuneCountInString(a.Term), utf8.RuneCountInString(b.Term); la != lb {
+			return la < lb
+		}
+		return a.Term < b.Term
+	})
+
+	var kept []Match
+	for _, c := range byLen {
+		overlaps := false
+		for _, k := range kept {
+			if c.Start < k.End && k.Start < c.End {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			kept = append(kept, c)
+		}
+	}
+
+	sort.Slice(kept, func(i, j int) bool { return kept[i].Start < kept[j].Start })
+	return kept
+}
+
+func equalRunes(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
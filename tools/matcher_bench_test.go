@@ -0,0 +1,155 @@
+package tools
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"coreheadlines/lang"
+	"coreheadlines/words"
+)
+
+// legacyMatcher is the regexp-per-term implementation the Aho-Corasick
+// Matcher replaced, kept here as the baseline for the benchmarks.
+type legacyMatcher struct {
+	countryTerms   []string
+	countryRegexps []*regexp.Regexp
+	emojiTerms     []string
+	emojiRegexps   []*regexp.Regexp
+}
+
+func newLegacyMatcher(d *Dictionaries) *legacyMatcher {
+	lm := &legacyMatcher{}
+	lm.countryTerms, lm.countryRegexps = compileTermRegexps(d.Countries)
+	lm.emojiTerms, lm.emojiRegexps = compileTermRegexps(d.Emoji)
+	return lm
+}
+
+func compileTermRegexps(dict map[string]string) ([]string, []*regexp.Regexp) {
+	terms := make([]string, 0, len(dict))
+	for term := range dict {
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		return len(terms[i]) > len(terms[j])
+	})
+
+	res := make([]*regexp.Regexp, len(terms))
+	for i, term := range terms {
+		res[i] = regexp.MustCompile(termPattern(term))
+	}
+	return terms, res
+}
+
+// termPattern matches term as a whole word. Terms with an uppercase letter
+// are case-sensitive; others ignore case. Word boundaries are only required
+// next to word characters, so "u.s." still matches before a space.
+func termPattern(term string) string {
+	pattern := regexp.QuoteMeta(term)
+	if first, _ := utf8.DecodeRuneInString(term); words.IsWordRune(first) {
+		pattern = `\b` + pattern
+	}
+	if last, _ := utf8.DecodeLastRuneInString(term); words.IsWordRune(last) {
+		pattern += `\b`
+	}
+	if !hasUpper(term) {
+		pattern = `(?i)` + pattern
+	}
+	return pattern
+}
+
+// terms runs every regexp over the title and returns the matching terms.
+func (lm *legacyMatcher) terms(title string) []string {
+	lowerTitle := strings.ToLower(title)
+	var found []string
+	for i, re := range lm.countryRegexps {
+		if re.MatchString(title) && contextAllows(lm.countryTerms[i], lowerTitle) {
+			found = append(found, lm.countryTerms[i])
+		}
+	}
+	for i, re := range lm.emojiRegexps {
+		if re.MatchString(title) && contextAllows(lm.emojiTerms[i], lowerTitle) {
+			found = append(found, lm.emojiTerms[i])
+		}
+	}
+	return found
+}
+
+// contextAllows applies the term's context rule, if any, to the title.
+func contextAllows(term, lowerTitle string) bool {
+	return contextReason(term, lowerTitle) == ""
+}
+
+var benchTitles = []string{
+	"U.S. stocks rally as Dow climbs to record high",
+	"Dow Chemical cuts jobs as demand slows",
+	"Swiss franc jumps after SNB surprise",
+	"Crude oil prices fall as OPEC+ weighs output hike",
+	"China and Russia sign new energy deal",
+	"Show HN: I built a tiny database in Rust",
+	"Microsoft patches zero-day exploited in ransomware attacks",
+	"Bitcoin slides below $60,000 as ETF outflows grow",
+	"UK inflation eases, boosting hopes of Bank of England rate cut",
+	"Israel and Iran trade strikes as Gaza talks stall",
+}
+
+func benchDictionaries(b *testing.B) *Dictionaries {
+	b.Helper()
+	d, problems, err := ReadDictionaries("")
+	if err != nil || d == nil {
+		b.Fatalf("embedded dictionaries: %v %v", err, problems)
+	}
+	return d
+}
+
+// Cold start: what every Lambda cold start pays before the first title gets
+// its emojis, from reading the embedded dictionaries on. The matcher side
+// runs installDictionaries end to end, topic and entity indexes included,
+// and builds the English matcher for the first title; the other languages'
+// are built only when one of their titles comes along.
+
+func BenchmarkColdStartRegexp(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		newLegacyMatcher(benchDictionaries(b)).terms(benchTitles[0])
+	}
+}
+
+func BenchmarkColdStartMatcher(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		installDictionaries(benchDictionaries(b))
+		GetEmojis(benchTitles[0], "")
+	}
+}
+
+// Every matcher built, as once titles in all languages have come along.
+func BenchmarkColdStartMatcherAllLanguages(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		installDictionaries(benchDictionaries(b))
+		for _, code := range lang.Languages() {
+			GetEmojis(benchTitles[0], code)
+		}
+		Classify(benchTitles[0], "", nil)
+		ExtractEntities(benchTitles[0])
+	}
+}
+
+// Per title: finding the terms in one headline.
+
+func BenchmarkTitleRegexp(b *testing.B) {
+	lm := newLegacyMatcher(benchDictionaries(b))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lm.terms(benchTitles[i%len(benchTitles)])
+	}
+}
+
+func BenchmarkTitleMatcher(b *testing.B) {
+	d := benchDictionaries(b)
+	m := NewMatcher(dictionaryTerms(d.Countries, d.Emoji))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SelectLongest(m.FindAll(benchTitles[i%len(benchTitles)]))
+	}
+}
@@ -0,0 +1,196 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// inflectionGroups groups the lowercase terms of a dictionary that stem to
+// the same phrase under stem. Each group has at least two terms and starts
+// with its base form, the shortest term.
+func inflectionGroups(dict map[string]string, stem func(string) string) [][]string {
+	byStem := make(map[string][]string)
+	for term := range dict {
+		if !hasUpper(term) {
+			key := stemPhrase(term, stem)
+			byStem[key] = append(byStem[key], term)
+		}
+	}
+
+	var groups [][]string
+	for _, terms := range byStem {
+		if len(terms) < 2 {
+			continue
+		}
+		sort.Slice(terms, func(i, j int) bool { return baseFormLess(terms[i], terms[j]) })
+		groups = append(groups, terms)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0] < groups[j][0] })
+	return groups
+}
+
+// baseFormLess orders the shortest term first, so "trap" is kept over
+// "traps", "trapped" and "trapping".
+func baseFormLess(a, b string) bool {
+	la, lb := utf8.RuneCountInString(a), utf8.RuneCountInString(b)
+	if la != lb {
+		return la < lb
+	}
+	return a < b
+}
+
+// CollapseInflections rewrites the countries and emoji files in dir so each
+// key lists only base forms: of the terms under one key that stem alike,
+// the shortest is kept. Terms with a context rule are never dropped, nor
+// are terms whose stem is also listed under another key, since there the
+// exact form decides which emoji wins ("stocks" 📈, "stock" 💼). Files
+// missing from dir are taken from the embedded defaults and written to dir.
+// It returns one line per dropped term.
+func CollapseInflections(dir string) ([]string, error) {
+	if dir == "" {
+		return nil, errors.New("no dictionary directory")
+	}
+
+	rulesData, err := readDictFile(dir, RulesFile)
+	if err != nil {
+		return nil, err
+	}
+	var rules map[string]json.RawMessage
+	if err := json.Unmarshal(rulesData, &rules); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", RulesFile, err)
+	}
+
+	var report []string
+	for _, name := range []string{CountriesFile, EmojisFile} {
+		data, err := readDictFile(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		entries, err := readOrderedDict(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", name, err)
+		}
+
+		stemKeys := make(map[string]map[string]bool) // stem -> keys listing it
+		for _, e := range entries {
+			for _, term := range e.terms {
+				stem := StemPhrase(term)
+				if stemKeys[stem] == nil {
+					stemKeys[stem] = make(map[string]bool)
+				}
+				stemKeys[stem][e.key] = true
+			}
+		}
+
+		for i, e := range entries {
+			kept, dropped := collapseTerms(e.terms, func(term string) bool {
+				_, ok := rules[term]
+				return ok || len(stemKeys[StemPhrase(term)]) > 1
+			})
+			entries[i].terms = kept
+			for term, base := range dropped {
+				report = append(report, fmt.Sprintf("%s: %s: %q -> %q", name, e.key, term, base))
+			}
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, name), writeOrderedDict(entries), 0o644); err != nil {
+			return nil, fmt.Errorf("write %s: %w", name, err)
+		}
+	}
+	sort.Strings(report)
+	return report, nil
+}
+
+type dictEntry struct {
+	key   string
+	terms []string
+}
+
+// collapseTerms keeps, in the original order, each term that is the base
+// form of its stem group (or pinned), and maps every dropped term to the
+// base form that replaces it.
+func collapseTerms(terms []string, pinned func(string) bool) ([]string, map[string]string) {
+	base := make(map[string]string) // stem -> base form
+	for _, term := range terms {
+		if hasUpper(term) {
+			continue
+		}
+		key := StemPhrase(term)
+		if b, ok := base[key]; !ok || baseFormLess(term, b) {
+			base[key] = term
+		}
+	}
+
+	var kept []string
+	dropped := make(map[string]string)
+	for _, term := range terms {
+		if hasUpper(term) || pinned(term) {
+			kept = append(kept, term)
+			continue
+		}
+		if b := base[StemPhrase(term)]; b != term {
+			dropped[term] = b
+			continue
+		}
+		kept = append(kept, term)
+	}
+	return kept, dropped
+}
+
+func readOrderedDict(data []byte) ([]dictEntry, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, errors.New("top level must be an object")
+	}
+
+	var entries []dictEntry
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		e := dictEntry{key: tok.(string)}
+		if err := dec.Decode(&e.terms); err != nil {
+			return nil, fmt.Errorf("value of %q: %w", e.key, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// writeOrderedDict formats entries the way the data files are kept: one key
+// per line, terms inline.
+func writeOrderedDict(entries []dictEntry) []byte {
+	quote := func(s string) string {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.Encode(s)
+		return strings.TrimSuffix(buf.String(), "\n")
+	}
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for i, e := range entries {
+		quoted := make([]string, len(e.terms))
+		for j, term := range e.terms {
+			quoted[j] = quote(term)
+		}
+		fmt.Fprintf(&b, "  %s: [%s]", quote(e.key), strings.Join(quoted, ", "))
+		if i < len(entries)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("}\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,120 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxHops    = 5
+	defaultHopTimeout = 5 * time.Second
+)
+
+// Slow wrappers get more time per hop than the default.
+var hostTimeouts = map[string]time.Duration{
+	"news.google.com":             8 * time.Second,
+	"feeds.feedburner.com":        8 * time.Second,
+	"feedproxy.google.com":        8 * time.Second,
+	"tracking.tldrnewsletter.com": 8 * time.Second,
+}
+
+// LinkResolver follows redirect wrappers and shorteners (Feedburner, Google
+// News, newsletter trackers, t.co, bit.ly, ...) to the article they point
+// at. Each hop is a HEAD, falling back to GET when the server refuses HEAD.
+type LinkResolver struct {
+	client    *http.Client
+	maxHops   int
+	userAgent string
+}
+
+func NewLinkResolver(userAgent string) *LinkResolver {
+	return &LinkResolver{
+		client: &http.Client{
+			// We follow Location ourselves to bound hops and time each host
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		maxHops:   defaultMaxHops,
+		userAgent: userAgent,
+	}
+}
+
+func hopTimeout(host string) time.Duration {
+	if d, ok := hostTimeouts[strings.ToLower(host)]; ok {
+		return d
+	}
+	return defaultHopTimeout
+}
+
+// Resolve returns the canonicalized final URL after following redirects.
+// If the chain breaks part-way, the last URL reached is returned with the
+// error so callers can still use the progress made.
+func (r *LinkResolver) Resolve(ctx context.Context, link string) (string, error) {
+	current, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || !IsWebURL(link) {
+		return link, fmt.Errorf("not a web URL: %q", link)
+	}
+
+	for range r.maxHops {
+		next, err := r.hop(ctx, current)
+		if err != nil {
+			return CanonicalURL(current.String()), err
+		}
+		if next == nil {
+			return CanonicalURL(current.String()), nil
+		}
+		current = next
+	}
+
+	return CanonicalURL(current.String()), fmt.Errorf("too many redirects (> %d) for %q", r.maxHops, link)
+}
+
+// hop issues one request and returns the redirect target, or nil when u is
+// the final destination.
+func (r *LinkResolver) hop(ctx context.Context, u *url.URL) (*url.URL, error) {
+	hopCtx, cancel := context.WithTimeout(ctx, hopTimeout(u.Hostname()))
+	defer cancel()
+
+	resp, err := r.do(hopCtx, http.MethodHead, u)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented || resp.StatusCode == http.StatusForbidden) {
+		resp.Body.Close()
+		resp, err = r.do(hopCtx, http.MethodGet, u)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", u, err)
+	}
+	resp.Body.Close() // headers are all we need
+
+	if resp.StatusCode < 300 || resp.StatusCode > 399 {
+		return nil, nil
+	}
+
+	loc := resp.Header.Get("Location")
+	if loc == "" {
+		return nil, errors.New("redirect without Location from " + u.String())
+	}
+	next, err := u.Parse(loc) // Location may be relative
+	if err != nil {
+		return nil, fmt.Errorf("bad Location %q from %s: %w", loc, u, err)
+	}
+	if !IsWebURL(next.String()) {
+		return nil, nil
+	}
+	return next, nil
+}
+
+func (r *LinkResolver) do(ctx context.Context, method string, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", r.userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,*/*")
+	return r.client.Do(req)
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"coreheadlines/feeds"
+	"coreheadlines/lang"
 	"coreheadlines/typesPkg"
 
 	"golang.org/x/net/html/charset"
@@ -26,7 +27,16 @@ type AtomEntry struct {
 	Link  struct {
 		Href string `xml:"href,attr"`
 	} `xml:"link"`
-	ID string `xml:"id"`
+	ID        string `xml:"id"`
+	Published string `xml:"published"`
+	Updated   string `xml:"updated"`
+	Author    struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+	Categories []struct {
+		Term  string `xml:"term,attr"`
+		Label string `xml:"label,attr"`
+	} `xml:"category"`
 }
 
 type RSS struct {
@@ -45,6 +55,13 @@ type Item struct {
 	AtomLink struct {
 		Href string `xml:"href,attr"`
 	} `xml:"http://www.w3.org/2005/Atom link"`
+	Author      string   `xml:"author"`
+	Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description"`
+	Comments    string   `xml:"comments"`
+	PubDate     string   `xml:"pubDate"`
+	DCDate      string   `xml:"http://purl.org/dc/elements/1.1/ date"`
 }
 
 type SlashdotRDF struct {
@@ -52,8 +69,12 @@ type SlashdotRDF struct {
 }
 
 type SlashdotItem struct {
-	Title string `xml:"title"`
-	Link  string `xml:"link"`
+	Title   string `xml:"title"`
+	Link    string `xml:"link"`
+	Creator string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Subject string `xml:"http://purl.org/dc/elements/1.1/ subject"`
+	Section string `xml:"http://purl.org/rss/1.0/modules/slash/ section"`
+	DCDate  string `xml:"http://purl.org/dc/elements/1.1/ date"`
 }
 
 func decodeXML(body []byte, v any) error {
@@ -62,12 +83,72 @@ func decodeXML(body []byte, v any) error {
 	return decoder.Decode(v)
 }
 
+// prefixedGUID namespaces a feed-provided GUID with the feed header. GUIDs
+// that are permalinks get canonicalized; the pre-canonicalization form is
+// returned as legacy when it differs.
+func prefixedGUID(header, candidate string) (string, string) {
+	canonical := candidate
+	if IsWebURL(candidate) {
+		canonical = CanonicalURL(candidate)
+	}
+
+	prefix := ""
+	if header != "" {
+		prefix = header + ":"
+	}
+	return prefix + canonical, legacyGUID(prefix+candidate, prefix+canonical)
+}
+
+// cleanList trims entries and drops empty ones and repeats.
+func cleanList(values ...string) []string {
+	var out []string
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(html.UnescapeString(v))
+		if v == "" || seen[strings.ToLower(v)] {
+			continue
+		}
+		seen[strings.ToLower(v)] = true
+		out = append(out, v)
+	}
+	return out
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// acceptLanguage prefers the feed's language, keeping English as a fallback.
+func acceptLanguage(code string) string {
+	if code == "" || code == lang.English {
+		return "en-US,en;q=0.9"
+	}
+	return code + ",en;q=0.5"
+}
+
+func legacyGUID(old, current string) string {
+	if old == current {
+		return ""
+	}
+	return old
+}
+
 func ParseRSSFeed(ctx context.Context, userAgents typesPkg.Agents, feed feeds.FeedConfig) ([]typesPkg.MainStruct, error) {
 	client := &http.Client{
 		Timeout: 40 * time.Second,
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", feed.URL, nil)
+	feedURL := feed.URL
+	if feed.HN != nil {
+		feedURL = hnFeedURL(feed.URL, *feed.HN)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -86,7 +167,7 @@ func ParseRSSFeed(ctx context.Context, userAgents typesPkg.Agents, feed feeds.Fe
 
 	req.Header.Set("User-Agent", selectedUserAgent)
 	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml, text/xml, */*")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	req.Header.Set("Accept-Language", acceptLanguage(feed.Language))
 	req.Header.Set("Cache-Control", "no-cache")
 
 	if feed.EnhancedHeaders {
@@ -140,11 +221,16 @@ func ParseRSSFeed(ctx context.Context, userAgents typesPkg.Agents, feed feeds.Fe
 				continue
 			}
 
+			link := CanonicalURL(item.Link)
 			post := typesPkg.MainStruct{
-				GUID:   item.Link,
-				Title:  title,
-				Header: feed.Header,
-				Link:   item.Link,
+				GUID:       link,
+				Title:      title,
+				Header:     feed.Header,
+				Link:       link,
+				LegacyGUID: legacyGUID(item.Link, link),
+				Author:     strings.TrimSpace(item.Creator),
+				Categories: cleanList(item.Section, item.Subject),
+				Published:  parseFeedDate(item.DCDate),
 			}
 
 			posts = append(posts, post)
@@ -164,27 +250,33 @@ func ParseRSSFeed(ctx context.Context, userAgents typesPkg.Agents, feed feeds.Fe
 				continue
 			}
 
-			link := strings.TrimSpace(entry.Link.Href)
+			rawLink := strings.TrimSpace(entry.Link.Href)
+			link := CanonicalURL(rawLink)
 			candidate := strings.TrimSpace(entry.ID)
 
-			var guid string
+			var guid, legacy string
 			if candidate != "" {
-				if h != "" {
-					guid = h + ":" + candidate
-				} else {
-					guid = candidate
-				}
+				guid, legacy = prefixedGUID(h, candidate)
 			} else if link != "" {
-				guid = link // fallback — do NOT prefix
+				guid, legacy = link, legacyGUID(rawLink, link) // fallback — do NOT prefix
 			} else {
 				continue
 			}
 
+			var categories []string
+			for _, c := range entry.Categories {
+				categories = append(categories, firstNonEmpty(c.Label, c.Term))
+			}
+
 			post := typesPkg.MainStruct{
-				GUID:   guid,
-				Title:  title,
-				Header: feed.Header,
-				Link:   link,
+				GUID:       guid,
+				Title:      title,
+				Header:     feed.Header,
+				Link:       link,
+				LegacyGUID: legacy,
+				Author:     strings.TrimSpace(entry.Author.Name),
+				Categories: cleanList(categories...),
+				Published:  parseFeedDate(entry.Published, entry.Updated),
 			}
 			posts = append(posts, post)
 		}
@@ -196,6 +288,7 @@ func ParseRSSFeed(ctx context.Context, userAgents typesPkg.Agents, feed feeds.Fe
 		}
 
 		h := strings.ReplaceAll(feed.Header, " ", "")
+		hn := isHNRSS(feed.URL)
 
 		for _, item := range rss.Channel.Items {
 			title := strings.TrimSpace(item.Title)
@@ -211,27 +304,35 @@ func ParseRSSFeed(ctx context.Context, userAgents typesPkg.Agents, feed feeds.Fe
 				continue
 			}
 
+			rawLink := link
+			link = CanonicalURL(rawLink)
+
 			candidate := strings.TrimSpace(item.GUID)
 			if candidate == "" {
 				candidate = strings.TrimSpace(item.ItemID)
 			}
 
-			var guid string
+			var guid, legacy string
 			if candidate != "" {
-				if h != "" {
-					guid = h + ":" + candidate
-				} else {
-					guid = candidate
-				}
+				guid, legacy = prefixedGUID(h, candidate)
 			} else {
-				guid = link // fallback — do NOT prefix
+				guid, legacy = link, legacyGUID(rawLink, link) // fallback — do NOT prefix
 			}
 
 			post := typesPkg.MainStruct{
-				GUID:   guid,
-				Title:  title,
-				Header: feed.Header,
-				Link:   link,
+				GUID:       guid,
+				Title:      title,
+				Header:     feed.Header,
+				Link:       link,
+				LegacyGUID: legacy,
+				Author:     firstNonEmpty(item.Creator, item.Author),
+				Categories: cleanList(item.Categories...),
+				Published:  parseFeedDate(item.PubDate, item.DCDate),
+			}
+			if hn {
+				meta := parseHNDescription(item.Description)
+				post.Score, post.Comments = meta.Points, meta.Comments
+				post.CommentsLink = firstNonEmpty(item.Comments, meta.Thread)
 			}
 			posts = append(posts, post)
 		}
@@ -241,5 +342,15 @@ func ParseRSSFeed(ctx context.Context, userAgents typesPkg.Agents, feed feeds.Fe
 		return nil, fmt.Errorf("no news releases found in feed")
 	}
 
+	feedLang := feed.Language
+	if feedLang == "" {
+		feedLang = lang.English
+	}
+	for i := range posts {
+		posts[i].Lang = lang.Guess(posts[i].Title, feedLang)
+		posts[i].Topics = Classify(posts[i].Title, posts[i].Lang, posts[i].Categories)
+		posts[i].Entities = ExtractEntities(posts[i].Title)
+	}
+
 	return posts, nil
 }
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"sort"
+	"strings"
+)
+
+// EmojiOptions control how many emojis GetEmojis picks and in what order.
+type EmojiOptions struct {
+	Max        int  `json:"max"`         // Emojis per title; 0 turns them off
+	FlagsFirst bool `json:"flags_first"` // Flags take the first slots whatever their score
+}
+
+// EmojiConfig is what GetEmojis uses; main overrides it from the environment.
+var EmojiConfig = EmojiOptions{Max: 4, FlagsFirst: true}
+
+// candidate is one emoji or flag a title produced, scored by the matches
+// behind it.
+type candidate struct {
+	emoji   string
+	flag    bool
+	score   float64
+	matches []Match
+}
+
+// matchScore rates one match: phrases beat single words ("crude oil" over
+// "oil"), the start of the title beats the end, and the emoji's configured
+// weight scales the lot.
+func matchScore(m Match, titleLen int, weight float64) float64 {
+	specificity := float64(len(strings.Fields(m.Term)))
+	position := 1.0
+	if titleLen > 0 {
+		position = 1.5 - 0.5*float64(m.Start)/float64(titleLen)
+	}
+	return weight * specificity * position
+}
+
+func emojiWeight(emoji string) float64 {
+	if w, ok := EmojiWeights[emoji]; ok {
+		return w
+	}
+	return 1
+}
+
+// scoreCandidates turns the selected matches into candidates, most relevant
+// first. Every occurrence adds to the score, so a repeated subject counts
+// more. Ties go to the earlier match.
+func scoreCandidates(ts *termSet, title string, matches []Match) []candidate {
+	var cands []candidate
+	index := make(map[string]int)
+	add := func(emoji string, flag bool, m Match) {
+		i, ok := index[emoji]
+		if !ok {
+			i = len(cands)
+			index[emoji] = i
+			cands = append(cands, candidate{emoji: emoji, flag: flag})
+		}
+		cands[i].score += matchScore(m, len(title), emojiWeight(emoji))
+		cands[i].matches = append(cands[i].matches, m)
+	}
+
+	for _, m := range matches {
+		for _, o := range ts.outputs(m.Term) {
+			add(o.emoji, o.flag, m)
+		}
+	}
+
+	// matches come in text order, so cands already are by first occurrence
+	sort.SliceStable(cands, func(i, j int) bool { return cands[i].score > cands[j].score })
+	return cands
+}
+
+type termOutput struct {
+	emoji string
+	flag  bool
+}
+
+// outputs lists what a term produces: a flag, an emoji or both.
+func (ts *termSet) outputs(term string) []termOutput {
+	var outs []termOutput
+	if code, ok := ts.countries[term]; ok {
+		if flag := countryCodeToFlag(code); flag != "" {
+			outs = append(outs, termOutput{emoji: flag, flag: true})
+		}
+	}
+	if emoji := ts.emoji[term]; emoji != "" {
+		outs = append(outs, termOutput{emoji: emoji})
+	}
+	return outs
+}
+
+// pickCandidates orders cands for display under opts and splits off the ones
+// beyond the cap.
+func pickCandidates(cands []candidate, opts EmojiOptions) (picked, dropped []candidate) {
+	ordered := append([]candidate(nil), cands...)
+	if opts.FlagsFirst {
+		sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].flag && !ordered[j].flag })
+	}
+	n := min(max(opts.Max, 0), len(ordered))
+	return ordered[:n], ordered[n:]
+}
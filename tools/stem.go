@@ -0,0 +1,314 @@
+package tools
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"coreheadlines/lang"
+	"coreheadlines/words"
+)
+
+// Stem reduces an English word to a base form shared by its inflections
+// ("traps", "trapping" and "trapped" all give "trap"), using step 1 of the
+// Porter stemmer with the Porter2 plural rules. The result is a matching
+// key, not always a real word ("rallies" gives "ralli"). Words that are not
+// plain ASCII letters come back lowercased but otherwise untouched.
+func Stem(word string) string {
+	w := strings.ToLower(word)
+	if base, ok := irregularForms[w]; ok {
+		w = base
+	}
+	if len(w) <= 2 || invariantWords[w] || !isASCIILetters(w) {
+		return w
+	}
+	b := []byte(w)
+	b = stepPlural(b)
+	b = stepEdIng(b)
+	b = stepY(b)
+	return string(b)
+}
+
+// Irregular past forms common in headlines, mapped to the base they share
+// with the regular inflections.
+var irregularForms = map[string]string{
+	"fell": "fall", "fallen": "fall",
+	"rose": "rise", "risen": "rise",
+	"sank": "sink", "sunk": "sink",
+	"slid":   "slide",
+	"struck": "strike",
+	"sold":   "sell",
+	"bought": "buy",
+	"spent":  "spend",
+	"lost":   "lose",
+	"froze":  "freeze", "frozen": "freeze",
+	"stole": "steal", "stolen": "steal",
+}
+
+// Words the plural rule would wrongly strip ("news" is not "new").
+var invariantWords = map[string]bool{
+	"news": true, "series": true, "species": true,
+}
+
+func isASCIILetters(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isConsonant follows Porter: y is a consonant unless it follows one.
+func isConsonant(b []byte, i int) bool {
+	switch b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !isConsonant(b, i-1)
+	}
+	return true
+}
+
+// measure counts the vowel-consonant sequences in b.
+func measure(b []byte) int {
+	m, i := 0, 0
+	for i < len(b) && isConsonant(b, i) {
+		i++
+	}
+	for i < len(b) {
+		for i < len(b) && !isConsonant(b, i) {
+			i++
+		}
+		if i == len(b) {
+			break
+		}
+		for i < len(b) && isConsonant(b, i) {
+			i++
+		}
+		m++
+	}
+	return m
+}
+
+func hasVowel(b []byte) bool {
+	for i := range b {
+		if !isConsonant(b, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// endsCVC reports a consonant-vowel-consonant ending whose last letter is
+// not w, x or y ("hop", not "snow").
+func endsCVC(b []byte) bool {
+	n := len(b)
+	if n < 3 || !isConsonant(b, n-3) || isConsonant(b, n-2) || !isConsonant(b, n-1) {
+		return false
+	}
+	c := b[n-1]
+	return c != 'w' && c != 'x' && c != 'y'
+}
+
+func hasSuffix(b []byte, s string) bool {
+	return len(b) >= len(s) && string(b[len(b)-len(s):]) == s
+}
+
+func stepPlural(b []byte) []byte {
+	switch {
+	case hasSuffix(b, "sses"):
+		return b[:len(b)-2]
+	case hasSuffix(b, "ied"), hasSuffix(b, "ies"):
+		// "ties" -> "tie" but "rallies" -> "ralli"
+		if len(b) > 4 {
+			return b[:len(b)-2]
+		}
+		return b[:len(b)-1]
+	case hasSuffix(b, "ss"), hasSuffix(b, "us"):
+		return b
+	case hasSuffix(b, "s"):
+		// Drop only if a vowel comes before the letter preceding the s,
+		// which keeps "gas" and "bus"
+		if hasVowel(b[:len(b)-2]) {
+			return b[:len(b)-1]
+		}
+	}
+	return b
+}
+
+func stepEdIng(b []byte) []byte {
+	if hasSuffix(b, "eed") {
+		if measure(b[:len(b)-3]) > 0 {
+			return b[:len(b)-1]
+		}
+		return b
+	}
+
+	var stem []byte
+	switch {
+	case hasSuffix(b, "ed") && hasVowel(b[:len(b)-2]):
+		stem = b[:len(b)-2]
+	case hasSuffix(b, "ing") && hasVowel(b[:len(b)-3]):
+		stem = b[:len(b)-3]
+	default:
+		return b
+	}
+
+	n := len(stem)
+	switch {
+	case hasSuffix(stem, "at"), hasSuffix(stem, "bl"), hasSuffix(stem, "iz"):
+		return append(stem, 'e')
+	case n >= 2 && stem[n-1] == stem[n-2] && isConsonant(stem, n-1) &&
+		stem[n-1] != 'l' && stem[n-1] != 's' && stem[n-1] != 'z':
+		return stem[:n-1]
+	case measure(stem) == 1 && endsCVC(stem):
+		return append(stem, 'e')
+	}
+	return stem
+}
+
+// stepY turns a final y after a consonant into i, so "rally" meets "rallies".
+func stepY(b []byte) []byte {
+	n := len(b)
+	if n > 2 && b[n-1] == 'y' && isConsonant(b, n-2) {
+		b[n-1] = 'i'
+	}
+	return b
+}
+
+// stemmerFor picks the word stemmer for a language: Stem for English and
+// plural rules for Spanish and Portuguese. Other languages are only
+// lowercased, as the English rules would cut their words at random.
+func stemmerFor(language string) func(string) string {
+	switch {
+	case lang.IsEnglish(language):
+		return Stem
+	case language == lang.Spanish:
+		return stemSpanish
+	case language == lang.Portuguese:
+		return stemPortuguese
+	}
+	return strings.ToLower
+}
+
+// Acute accents move or vanish in the Spanish plural ("elección",
+// "elecciones"), so the stems leave them out.
+var spanishAccents = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u")
+
+// stemSpanish reduces a Spanish word and its plural to one key, without
+// accents: "elección" and "elecciones" both give "eleccion", "fraude" and
+// "fraudes" give "fraud", "luz" and "luces" give "luz".
+func stemSpanish(word string) string {
+	w := strings.ToLower(word)
+	if rest, ok := strings.CutSuffix(w, "ces"); ok && len(rest) >= 2 {
+		return spanishAccents.Replace(rest + "z")
+	}
+	return spanishAccents.Replace(dropFinalE(dropPluralS(w)))
+}
+
+// Portuguese accents, like the Spanish ones, differ between forms
+// ("juiz", "juízes").
+var portugueseAccents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a",
+	"é", "e", "ê", "e", "í", "i",
+	"ó", "o", "ô", "o", "õ", "o", "ú", "u",
+)
+
+// Portuguese plural endings that change more than the final s, and the
+// singular ones they replace.
+var portuguesePlurals = []struct{ plural, singular string }{
+	{"ões", "ão"}, {"ães", "ão"},
+	{"ais", "al"}, {"éis", "el"}, {"óis", "ol"}, {"uis", "ul"},
+	{"ns", "m"},
+}
+
+// stemPortuguese reduces a Portuguese word and its plural to one key,
+// without accents: "eleição" and "eleições" both give "eleiçao", "crise"
+// and "crises" give "cris", "jornal" and "jornais" give "jornal".
+func stemPortuguese(word string) string {
+	w := strings.ToLower(word)
+	for _, p := range portuguesePlurals {
+		// With one letter left it is no such plural: "mais" is not "mal"
+		if rest, ok := strings.CutSuffix(w, p.plural); ok && len(rest) >= 2 {
+			return portugueseAccents.Replace(rest + p.singular)
+		}
+	}
+	return portugueseAccents.Replace(dropFinalE(dropPluralS(w)))
+}
+
+// dropPluralS drops the s of a plural ending in a vowel and s, but not
+// after an accented vowel, which keeps "país".
+func dropPluralS(w string) string {
+	if n := len(w); n > 3 && w[n-1] == 's' && isVowel(w[n-2]) {
+		return w[:n-1]
+	}
+	return w
+}
+
+// dropFinalE drops a final e after a consonant. Plurals in -es may add the
+// e ("flor", "flores") or keep the singular's ("fraude", "fraudes"), so
+// neither form keeps it.
+func dropFinalE(w string) string {
+	if n := len(w); n > 3 && w[n-1] == 'e' && !isVowel(w[n-2]) {
+		return w[:n-1]
+	}
+	return w
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
+
+// stemmedText is text lowercased with every word replaced by its stem. Each
+// rune remembers the byte span of the original text it stands for; all runes
+// of a stemmed word span the whole original word.
+type stemmedText struct {
+	runes  []rune
+	starts []int
+	ends   []int
+}
+
+func stemText(text string, stem func(string) string) stemmedText {
+	st := stemmedText{
+		runes:  make([]rune, 0, len(text)),
+		starts: make([]int, 0, len(text)),
+		ends:   make([]int, 0, len(text)),
+	}
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if !words.IsWordRune(r) {
+			st.runes = append(st.runes, unicode.ToLower(r))
+			st.starts = append(st.starts, i)
+			st.ends = append(st.ends, i+size)
+			i += size
+			continue
+		}
+
+		j := i
+		for j < len(text) {
+			r, size := utf8.DecodeRuneInString(text[j:])
+			if !words.IsWordRune(r) {
+				break
+			}
+			j += size
+		}
+		for _, sr := range stem(text[i:j]) {
+			st.runes = append(st.runes, sr)
+			st.starts = append(st.starts, i)
+			st.ends = append(st.ends, j)
+		}
+		i = j
+	}
+	return st
+}
+
+// StemPhrase stems each English word of s, keeping the separators: "Crude
+// oil prices" gives "crude oil price".
+func StemPhrase(s string) string {
+	return stemPhrase(s, Stem)
+}
+
+func stemPhrase(s string, stem func(string) string) string {
+	return string(stemText(s, stem).runes)
+}
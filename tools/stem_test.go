@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStemSpanish(t *testing.T) {
+	tests := []struct {
+		word, want string
+	}{
+		// -s after a vowel
+		{"guerra", "guerra"},
+		{"guerras", "guerra"},
+		{"ataques", "ataque"},
+		// -es after a consonant, accents dropped
+		{"elección", "eleccion"},
+		{"elecciones", "eleccion"},
+		{"arancel", "arancel"},
+		{"aranceles", "arancel"},
+		{"leyes", "ley"},
+		// -es over a singular in -e
+		{"fraude", "fraud"},
+		{"fraudes", "fraud"},
+		{"presidente", "president"},
+		{"presidentes", "president"},
+		{"clase", "clas"},
+		{"clases", "clas"},
+		// -ces for -z
+		{"luz", "luz"},
+		{"luces", "luz"},
+		// an accented vowel before the s is no plural
+		{"país", "pais"},
+		{"países", "pais"},
+		// too short to touch
+		{"mes", "mes"},
+		{"de", "de"},
+	}
+	for _, tt := range tests {
+		if got := stemSpanish(tt.word); got != tt.want {
+			t.Errorf("stemSpanish(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestStemPortuguese(t *testing.T) {
+	tests := []struct {
+		word, want string
+	}{
+		// -s after a vowel
+		{"incêndio", "incendio"},
+		{"incêndios", "incendio"},
+		{"queimadas", "queimada"},
+		// -es over a singular in -e or a consonant
+		{"crise", "cris"},
+		{"crises", "cris"},
+		{"bases", "bas"},
+		{"torre", "torr"},
+		{"torres", "torr"},
+		{"mulher", "mulher"},
+		{"mulheres", "mulher"},
+		{"juiz", "juiz"},
+		{"juízes", "juiz"},
+		// -ões and -ães for -ão
+		{"eleição", "eleiçao"},
+		{"eleições", "eleiçao"},
+		{"alemães", "alemao"},
+		// -is for -l
+		{"jornais", "jornal"},
+		{"papéis", "papel"},
+		{"anzóis", "anzol"},
+		{"azuis", "azul"},
+		// -ns for -m
+		{"homens", "homem"},
+		// an accented vowel before the s is no plural
+		{"país", "pais"},
+		{"países", "pais"},
+		// one letter before -ais is no plural of -al
+		{"mais", "mai"},
+	}
+	for _, tt := range tests {
+		if got := stemPortuguese(tt.word); got != tt.want {
+			t.Errorf("stemPortuguese(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestGetEmojisPlurals(t *testing.T) {
+	tests := []struct {
+		title, lang, want string
+	}{
+		{"Nuevos fraudes bancarios en España", "es", "🚨"},
+		{"Incêndios na Amazônia batem recorde em agosto", "pt", "🔥"},
+		{"Queimadas avançam no Pantanal", "pt", "🔥"},
+	}
+	for _, tt := range tests {
+		if !slices.Contains(pickEmojis(tt.title, tt.lang), tt.want) {
+			t.Errorf("GetEmojis(%q, %q) = %q, want it to include %s", tt.title, tt.lang, GetEmojis(tt.title, tt.lang), tt.want)
+		}
+	}
+}
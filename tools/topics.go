@@ -0,0 +1,230 @@
+package tools
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"coreheadlines/lang"
+)
+
+// Topic is a broad subject articles are classified into. Every term of the
+// seed emojis in the dictionaries counts seedWeight towards it; Terms add
+// keywords of their own or override a seeded term's weight. Terms match on
+// stems, so words with an everyday sense besides the topic's ("shares" for
+// "sharing", "war" in "Star Wars") weigh less than minTopicScore and need
+// a second hit.
+type Topic struct {
+	Name   string // lowercase; the hashtag and the value in topics.contains()
+	Emojis []string
+	Terms  map[string]float64
+}
+
+const (
+	seedWeight    = 0.6 // a seeded term alone is not enough
+	minTopicScore = 1.0
+	maxTopics     = 3
+)
+
+var Topics = []Topic{
+	{
+		Name:   "markets",
+		Emojis: []string{"📉", "📈", "🐂", "🐻", "💼", "🐳", "🔔", "🗂️", "🏙️", "🗽"},
+		Terms: map[string]float64{
+			"stock market": 1.5, "wall street": 1.5, "nasdaq": 1, "s&p 500": 1, "dow jones": 1, "ftse": 1, "nikkei": 1,
+			"earnings": 1, "ipo": 1, "shares": 0.5, "bond yields": 1, "treasury yields": 1, "hedge fund": 1, "investor": 1,
+		},
+	},
+	{
+		Name:   "economy",
+		Emojis: []string{"👨‍🏫", "🏛️", "💵", "💶", "💴", "💷", "👷‍♂️"},
+		Terms: map[string]float64{
+			"inflation": 1, "gdp": 1, "cpi": 1, "recession": 1, "interest rates": 1, "central bank": 1,
+			"unemployment": 1, "jobs report": 1.5, "tariff": 1, "trade war": 1, "economy": 1,
+		},
+	},
+	{
+		Name:   "crypto",
+		Emojis: []string{"💱", "🔗"},
+		Terms: map[string]float64{
+			"bitcoin": 1.5, "ethereum": 1.5, "crypto": 1.5, "cryptocurrency": 1.5, "stablecoin": 1.5, "btc": 1, "blockchain": 1,
+		},
+	},
+	{
+		Name:   "energy",
+		Emojis: []string{"🛢️", "⛽", "☀️", "🔋", "⚡️"},
+		Terms: map[string]float64{
+			"oil": 1, "opec": 1.5, "natural gas": 1, "lng": 1, "solar": 1, "nuclear power": 1.5, "energy": 1, "power grid": 1,
+		},
+	},
+	{
+		Name:   "security",
+		Emojis: []string{"🔒", "🔓", "🔐", "🎣"},
+		Terms: map[string]float64{
+			"vulnerability": 1.5, "cve": 1.5, "ransomware": 1.5, "malware": 1.5, "zero-day": 1.5, "exploit": 1,
+			"hacker": 1, "data breach": 1.5, "phishing": 1.5, "cybersecurity": 1.5, "cyberattack": 1.5, "infosec": 1.5,
+		},
+	},
+	{
+		Name:   "ai",
+		Emojis: []string{"🤖"},
+		Terms: map[string]float64{
+			"ai": 1.5, "artificial intelligence": 1.5, "machine learning": 1.5, "deep learning": 1.5, "llm": 1.5,
+			"openai": 1.5, "chatgpt": 1.5, "anthropic": 1.5, "gpt": 1, "neural network": 1, "generative": 1,
+		},
+	},
+	{
+		Name:   "tech",
+		Emojis: []string{"💻", "☁️", "📱", "🐧", "🍏", "🎮", "🕶️"},
+		Terms: map[string]float64{
+			"software": 1, "open source": 1, "developer": 1, "browser": 1, "linux": 1, "smartphone": 1, "iphone": 1,
+			"android": 1, "semiconductor": 1, "chip": 0.5, "programming": 1,
+		},
+	},
+	{
+		Name:   "startups",
+		Emojis: []string{"👨‍💼", "🏋️‍♀️"},
+		Terms: map[string]float64{
+			"startup": 1.5, "start-up": 1.5, "founder": 1, "funding round": 1.5, "series a": 1.5, "series b": 1.5,
+			"seed round": 1.5, "venture capital": 1.5, "vc": 1, "y combinator": 1.5, "unicorn": 0.5, "valuation": 1,
+		},
+	},
+	{
+		Name:   "geopolitics",
+		Emojis: []string{"🗺️", "🕊️", "🧭", "🎩", "🚫", "⚔️", "💬", "🏔️"},
+		Terms: map[string]float64{
+			"geopolitics": 1.5, "sanctions": 1, "nato": 1.5, "ceasefire": 1.5, "diplomat": 1, "foreign minister": 1.5,
+			"invasion": 1.5, "annexation": 1.5, "war": 0.5, "summit": 0.5, "united nations": 1.5,
+		},
+	},
+	{
+		Name:   "defense",
+		Emojis: []string{"🪖", "🛡️", "⚓", "🛩️", "🚁", "🛸", "💣", "🥷", "🎖️"},
+		Terms: map[string]float64{
+			"military": 1, "missile": 1.5, "pentagon": 1.5, "drone": 1, "troop": 1, "airstrike": 1.5, "army": 1, "navy": 1,
+		},
+	},
+	{
+		Name:   "science",
+		Emojis: []string{"🔬", "🧬", "⚛️", "🛰️", "🧪"},
+		Terms: map[string]float64{
+			"scientist": 1, "researcher": 1, "nasa": 1.5, "physics": 1.5, "telescope": 1.5, "astronomer": 1.5, "species": 1,
+		},
+	},
+	{
+		Name:   "health",
+		Emojis: []string{"💊", "🏥", "💉", "👨‍⚕️"},
+		Terms: map[string]float64{
+			"fda": 1.5, "vaccine": 1.5, "cancer": 1.5, "hospital": 1, "outbreak": 1, "pandemic": 1.5, "disease": 1,
+		},
+	},
+	{
+		Name:   "climate",
+		Emojis: []string{"🌱"},
+		Terms: map[string]float64{
+			"climate change": 1.5, "global warming": 1.5, "emission": 1, "carbon": 1, "heatwave": 1.5, "wildfire": 1,
+			"net zero": 1.5, "climate": 1,
+		},
+	},
+}
+
+// The topic vocabulary, built from Topics and the current dictionaries:
+// term -> topic name -> weight.
+var (
+	topicTerms   map[string]map[string]float64
+	topicMatcher func() *Matcher // built on first use
+)
+
+func buildTopicIndex(emoji map[string]string) {
+	byEmoji := make(map[string][]string)
+	for term, e := range emoji {
+		byEmoji[e] = append(byEmoji[e], term)
+	}
+
+	topicTerms = make(map[string]map[string]float64)
+	set := func(term, topic string, w float64) {
+		if topicTerms[term] == nil {
+			topicTerms[term] = make(map[string]float64)
+		}
+		topicTerms[term][topic] = w
+	}
+	for _, t := range Topics {
+		for _, e := range t.Emojis {
+			for _, term := range byEmoji[e] {
+				set(term, t.Name, seedWeight)
+			}
+		}
+		for term, w := range t.Terms {
+			set(term, t.Name, w)
+		}
+	}
+
+	terms := make([]string, 0, len(topicTerms))
+	for term := range topicTerms {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+	topicMatcher = sync.OnceValue(func() *Matcher { return NewMatcher(terms) })
+}
+
+// Classify returns up to three topics for an article, best first. Title
+// keywords count for English titles only; feed categories ("Security",
+// "Artificial Intelligence") count in any language, and a category naming
+// a topic outright settles it.
+func Classify(title, language string, categories []string) []string {
+	scores := make(map[string]float64)
+	addText := func(text string) {
+		for _, m := range SelectLongest(topicMatcher().FindAll(text)) {
+			for topic, w := range topicTerms[m.Term] {
+				scores[topic] += w
+			}
+		}
+	}
+
+	if lang.IsEnglish(language) {
+		addText(title)
+	}
+	for _, c := range categories {
+		if hasTopic(strings.ToLower(c)) {
+			scores[strings.ToLower(c)] += minTopicScore
+		}
+		addText(c)
+	}
+
+	var topics []string
+	for topic, s := range scores {
+		if s >= minTopicScore {
+			topics = append(topics, topic)
+		}
+	}
+	sort.Slice(topics, func(i, j int) bool {
+		if scores[topics[i]] != scores[topics[j]] {
+			return scores[topics[i]] > scores[topics[j]]
+		}
+		return topics[i] < topics[j]
+	})
+	if len(topics) > maxTopics {
+		topics = topics[:maxTopics]
+	}
+	return topics
+}
+
+func hasTopic(name string) bool {
+	for _, t := range Topics {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Hashtags renders topics as Telegram hashtags: "#markets #ai".
+func Hashtags(topics []string) string {
+	tags := make([]string, 0, len(topics))
+	for _, t := range topics {
+		if t = strings.ReplaceAll(strings.TrimSpace(t), " ", "_"); t != "" {
+			tags = append(tags, "#"+t)
+		}
+	}
+	return strings.Join(tags, " ")
+}
@@ -0,0 +1,43 @@
+package tools
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		title string
+		want  []string
+	}{
+		{"Nasdaq and Wall Street rally as tech shares climb", []string{"markets"}},
+		{"Stocks slide as shares of chipmakers fall", []string{"markets"}},
+		{"NATO allies meet as war in Ukraine enters third year", []string{"geopolitics"}},
+		{"Ransomware gang exploits zero-day in VPN appliances", []string{"security"}},
+
+		// An everyday sense of a topic word is no topic
+		{"How I'm sharing my dotfiles", nil},
+		{"Meta shared user data with advertisers, regulator says", nil},
+		{"Star Wars sequel announced", nil},
+	}
+	for _, tt := range tests {
+		got := Classify(tt.title, "", nil)
+		for _, topic := range tt.want {
+			if !slices.Contains(got, topic) {
+				t.Errorf("Classify(%q) = %q, want %q among them", tt.title, got, topic)
+			}
+		}
+		if tt.want == nil && len(got) > 0 {
+			t.Errorf("Classify(%q) = %q, want none", tt.title, got)
+		}
+	}
+}
+
+func TestClassifyCategories(t *testing.T) {
+	if got := Classify("Le gouvernement annonce un plan", "fr", []string{"Security"}); !slices.Equal(got, []string{"security"}) {
+		t.Errorf("category naming a topic: got %q, want [security]", got)
+	}
+	if got := Classify("Bitcoin hits a record", "fr", nil); len(got) > 0 {
+		t.Errorf("non-English title: got %q, want none", got)
+	}
+}
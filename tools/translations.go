@@ -0,0 +1,166 @@
+package tools
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"coreheadlines/lang"
+)
+
+// StopwordsFile lists, per language, English terms that are everyday words
+// in that language ("un", "dos") and so must not fall back:
+//
+//	es/stopwords.json  ["un", "dos", ...]
+const StopwordsFile = "stopwords.json"
+
+// Translation is one language's dictionaries. They add to the English ones
+// rather than replace them: names like "Trump" or "Nasdaq" read the same in
+// every language, so a title is matched against its language's terms first
+// and then the English terms, minus the stopwords.
+type Translation struct {
+	Countries map[string]string // term -> ISO code
+	Emoji     map[string]string // term -> emoji
+	Stopwords map[string]bool   // English terms to leave out
+}
+
+// overlay returns the English maps with t's terms added over them.
+func (t *Translation) overlay(countries, emoji map[string]string) (map[string]string, map[string]string) {
+	merge := func(english, own map[string]string) map[string]string {
+		out := make(map[string]string, len(english)+len(own))
+		for term, key := range english {
+			if !t.Stopwords[term] {
+				out[term] = key
+			}
+		}
+		for term, key := range own {
+			out[term] = key
+		}
+		return out
+	}
+	return merge(countries, t.Countries), merge(emoji, t.Emoji)
+}
+
+// matcher finds the terms overlay returned: t's own match on stems by the
+// rules of language code, the English ones only as written.
+func (t *Translation) matcher(code string, countries, emoji map[string]string) *Matcher {
+	var own, english []string
+	for _, term := range dictionaryTerms(countries, emoji) {
+		_, inCountries := t.Countries[term]
+		_, inEmoji := t.Emoji[term]
+		if inCountries || inEmoji {
+			own = append(own, term)
+		} else {
+			english = append(english, term)
+		}
+	}
+	return newMatcher(own, english, stemmerFor(code))
+}
+
+// readTranslations reads the files of every language but English from
+// dir/<code>/, falling back to the embedded ones. Languages with none of
+// the files get no Translation and use the English dictionaries.
+func readTranslations(dir string, base *Dictionaries) (map[string]*Translation, []Problem, error) {
+	translations := make(map[string]*Translation)
+	var problems []Problem
+	for _, code := range lang.Languages() {
+		if lang.IsEnglish(code) {
+			continue
+		}
+
+		var files [3][]byte
+		found := false
+		for i, name := range []string{CountriesFile, EmojisFile, StopwordsFile} {
+			b, err := readOptionalDictFile(dir, code+"/"+name)
+			if err != nil {
+				return nil, nil, err
+			}
+			files[i], found = b, found || b != nil
+		}
+		if !found {
+			continue
+		}
+
+		t, tp := ValidateTranslation(code, files[0], files[1], files[2], base)
+		translations[code] = t
+		problems = append(problems, tp...)
+	}
+	return translations, problems, nil
+}
+
+// ValidateTranslation parses one language's files, any of which may be nil,
+// with the checks of ValidateDictionaries, and also flags terms that repeat
+// the English mapping and stopwords that are not English terms.
+func ValidateTranslation(code string, countries, emojis, stopwords []byte, base *Dictionaries) (*Translation, []Problem) {
+	var problems []Problem
+	t := &Translation{Countries: map[string]string{}, Emoji: map[string]string{}, Stopwords: map[string]bool{}}
+
+	if countries != nil {
+		var cp []Problem
+		t.Countries, cp = parseDictFile(code+"/"+CountriesFile, countries, stemmerFor(code), checkCountryCode)
+		problems = append(problems, cp...)
+	}
+	if emojis != nil {
+		var ep []Problem
+		t.Emoji, ep = parseDictFile(code+"/"+EmojisFile, emojis, stemmerFor(code), checkEmoji)
+		problems = append(problems, ep...)
+	}
+	if stopwords != nil {
+		var sp []Problem
+		t.Stopwords, sp = parseStopwordsFile(code+"/"+StopwordsFile, stopwords, func(term string) bool {
+			_, inCountries := base.Countries[term]
+			_, inEmoji := base.Emoji[term]
+			return inCountries || inEmoji
+		})
+		problems = append(problems, sp...)
+	}
+
+	// English terms apply anyway, so repeating one adds nothing
+	redundant := func(file string, own, english map[string]string) {
+		terms := make([]string, 0, len(own))
+		for term, key := range own {
+			if english[term] == key && !t.Stopwords[term] {
+				terms = append(terms, term)
+			}
+		}
+		sort.Strings(terms)
+		for _, term := range terms {
+			problems = append(problems, Problem{
+				Severity: SeverityWarning, File: code + "/" + file, Key: own[term], Term: term,
+				Msg: "redundant, the English dictionary already maps it",
+			})
+		}
+	}
+	redundant(CountriesFile, t.Countries, base.Countries)
+	redundant(EmojisFile, t.Emoji, base.Emoji)
+
+	return t, problems
+}
+
+func parseStopwordsFile(name string, data []byte, known func(term string) bool) (map[string]bool, []Problem) {
+	var problems []Problem
+	add := func(sev Severity, term, msg string) {
+		problems = append(problems, Problem{Severity: sev, File: name, Term: term, Msg: msg})
+	}
+
+	var terms []string
+	if err := json.Unmarshal(data, &terms); err != nil {
+		add(SeverityError, "", "malformed JSON: "+err.Error())
+		return nil, problems
+	}
+
+	out := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		switch {
+		case strings.TrimSpace(term) == "":
+			add(SeverityError, term, "empty term")
+		case out[term]:
+			add(SeverityWarning, term, "repeated term")
+		case !known(term):
+			// Most likely a casing mismatch, as with rules
+			add(SeverityError, term, "stopword that is in no English dictionary")
+		}
+		out[term] = true
+	}
+	return out, problems
+}
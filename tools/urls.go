@@ -0,0 +1,201 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Query parameters that only track where a click came from.
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "msclkid": true, "yclid": true,
+	"igshid": true, "mc_cid": true, "mc_eid": true, "mkt_tok": true,
+	"_hsenc": true, "_hsmi": true, "ref": true, "ref_src": true, "ref_url": true,
+	"cmpid": true, "ncid": true, "ocid": true, "smid": true, "sr_share": true,
+	"s_cid": true, "spm": true, "at_medium": true, "at_campaign": true,
+	"amp": true,
+}
+
+var trackingPrefixes = []string{"utm_", "__twitter_impression", "ga_"}
+
+func isTrackingParam(key string) bool {
+	k := strings.ToLower(key)
+	if trackingParams[k] {
+		return true
+	}
+	for _, p := range trackingPrefixes {
+		if strings.HasPrefix(k, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanonicalURL normalizes a link so the same page always yields the same
+// string: lowercase scheme and host, no default port, no fragment, no
+// tracking parameters, remaining parameters sorted, AMP variants folded back
+// to the regular page. Anything that isn't an absolute http(s) URL is
+// returned trimmed but otherwise untouched.
+func CanonicalURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return raw
+	}
+
+	u.Host = strings.ToLower(u.Host)
+	if (u.Scheme == "http" && u.Port() == "80") || (u.Scheme == "https" && u.Port() == "443") {
+		u.Host = u.Hostname()
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	unwrapAMPCache(u)
+	stripAMPPath(u)
+
+	if u.RawQuery != "" {
+		q := u.Query()
+		for k := range q {
+			if isTrackingParam(k) || (strings.EqualFold(k, "outputType") && strings.EqualFold(q.Get(k), "amp")) {
+				q.Del(k)
+			}
+		}
+		u.RawQuery = q.Encode() // Encode sorts by key
+	}
+	u.ForceQuery = false
+
+	if u.Path == "" {
+		u.Path = "/"
+	}
+
+	return u.String()
+}
+
+// unwrapAMPCache turns https://example-com.cdn.ampproject.org/c/s/example.com/a
+// into https://example.com/a.
+func unwrapAMPCache(u *url.URL) {
+	if !strings.HasSuffix(u.Host, ".cdn.ampproject.org") {
+		return
+	}
+
+	rest := u.Path
+	scheme := "http"
+	for _, p := range []string{"/c/s/", "/v/s/", "/i/s/"} {
+		if after, ok := strings.CutPrefix(rest, p); ok {
+			rest, scheme = after, "https"
+			break
+		}
+	}
+	for _, p := range []string{"/c/", "/v/", "/i/"} {
+		if after, ok := strings.CutPrefix(rest, p); ok {
+			rest = after
+			break
+		}
+	}
+
+	host, path, _ := strings.Cut(rest, "/")
+	if host == "" {
+		return
+	}
+	u.Scheme = scheme
+	u.Host = strings.ToLower(host)
+	u.Path = "/" + path
+	u.RawPath = ""
+}
+
+// stripAMPPath drops the usual AMP path markers: /amp/..., .../amp, .amp.html.
+func stripAMPPath(u *url.URL) {
+	p := u.Path
+	switch {
+	case strings.HasPrefix(p, "/amp/"):
+		p = strings.TrimPrefix(p, "/amp")
+	case strings.HasSuffix(p, "/amp") || strings.HasSuffix(p, "/amp/"):
+		p = strings.TrimSuffix(strings.TrimSuffix(p, "/"), "/amp")
+	case strings.HasSuffix(p, ".amp.html"):
+		p = strings.TrimSuffix(p, ".amp.html") + ".html"
+	case strings.HasSuffix(p, ".amp"):
+		p = strings.TrimSuffix(p, ".amp")
+	default:
+		return
+	}
+	u.Path = p
+	u.RawPath = ""
+}
+
+// IsWebURL reports whether s is an absolute http(s) URL.
+func IsWebURL(s string) bool {
+	u, err := url.Parse(strings.TrimSpace(s))
+	return err == nil && u.Host != "" && (strings.EqualFold(u.Scheme, "http") || strings.EqualFold(u.Scheme, "https"))
+}
+
+// FetchRelCanonical downloads the article page and returns the canonicalized
+// <link rel="canonical"> target, or "" when the page doesn't declare one.
+func FetchRelCanonical(ctx context.Context, client *http.Client, link, userAgent string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", link, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	// The tag lives in <head>; never read more than the first 512 KiB.
+	z := html.NewTokenizer(io.LimitReader(resp.Body, 512<<10))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return "", nil
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "head" {
+				return "", nil
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			if string(name) != "link" || !hasAttr {
+				continue
+			}
+
+			var rel, href string
+			for {
+				key, val, more := z.TagAttr()
+				switch string(key) {
+				case "rel":
+					rel = strings.ToLower(string(val))
+				case "href":
+					href = string(val)
+				}
+				if !more {
+					break
+				}
+			}
+			if rel != "canonical" || href == "" {
+				continue
+			}
+
+			target, err := resp.Request.URL.Parse(href) // resolve relative hrefs
+			if err != nil || !IsWebURL(target.String()) {
+				return "", nil
+			}
+			return CanonicalURL(target.String()), nil
+		}
+	}
+}
@@ -1,10 +1,51 @@
 package typesPkg
 
+import "time"
+
 type MainStruct struct {
 	GUID   string
 	Title  string
 	Link   string
 	Header string
+
+	Author     string    // dc:creator / author, when the feed provides it
+	Categories []string  // feed-provided categories, in feed order
+	Lang       string    // ISO 639-1 code detected from the title, e.g. "en", "es"
+	Topics     []string  // classified topics, best first (see tools.Topics)
+	Entities   []Entity  // people, companies and indices named in the title, in title order
+	Published  time.Time // feed-provided publication (or update) time; zero if undated
+
+	// Hacker News metadata from hnrss.org descriptions (zero elsewhere)
+	Score        int    // points
+	Comments     int    // comment count
+	CommentsLink string // discussion thread
+
+	// LegacyGUID is the GUID this item had before URL canonicalization, when
+	// different; published checks accept either so nothing is reposted.
+	LegacyGUID string
+
+	// AlsoCoveredBy lists the other outlets that ran the same story.
+	AlsoCoveredBy []Coverage
+
+	// Priority is the sum of matching feeds.PriorityRules; higher posts first.
+	Priority int
+
+	// MessageID is the channel message this article was posted as (0 if not
+	// posted yet); set by telegram.SendMessages.
+	MessageID int64
+}
+
+// Entity is a person, company or market index from the entity dictionary.
+type Entity struct {
+	ID     string `dynamodbav:"id"` // dictionary key, e.g. "nvidia"
+	Type   string `dynamodbav:"type"`
+	Name   string `dynamodbav:"name"`
+	Ticker string `dynamodbav:"ticker,omitempty"`
+}
+
+type Coverage struct {
+	Header string `dynamodbav:"header"`
+	Link   string `dynamodbav:"link"`
 }
 
 type Agents struct {
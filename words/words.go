@@ -0,0 +1,40 @@
+// Package words finds terms in headlines as whole words, the matching that
+// keyword filters, alert tags and dictionary context rules share.
+package words
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Contains reports whether term occurs in text with no word rune directly
+// before or after it, so "nvidia" is found in "Nvidia’s" and "war" in
+// "war—" but not in "software" or "war_room". Both are expected to be
+// lowercased.
+func Contains(text, term string) bool {
+	if term == "" {
+		return false
+	}
+	for from := 0; from <= len(text)-len(term); {
+		i := strings.Index(text[from:], term)
+		if i < 0 {
+			return false
+		}
+		start, end := from+i, from+i+len(term)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if (start == 0 || !IsWordRune(before)) && (end == len(text) || !IsWordRune(after)) {
+			return true
+		}
+		from = start + 1
+	}
+	return false
+}
+
+// IsWordRune reports whether r belongs to a word: a letter or digit in any
+// script, or '_', which joins identifiers like "foo_bar" into one word.
+// Punctuation such as ’ or — never does.
+func IsWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,40 @@
+package words
+
+import "testing"
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		text, term string
+		want       bool
+	}{
+		{"nvidia’s new chip", "nvidia", true},
+		{"the war—again", "war", true},
+		{"war", "war", true},
+		{"crude oil prices", "crude oil", true},
+		{"software update", "war", false},
+		{"inside the war_room", "war", false},
+		{"foo_bar released", "foo_bar", true},
+		{"eleições na argentina", "eleições", true},
+		{"reeleições", "eleições", false},
+		{"covid19 cases", "covid", false},
+		{"anything", "", false},
+	}
+	for _, tt := range tests {
+		if got := Contains(tt.text, tt.term); got != tt.want {
+			t.Errorf("Contains(%q, %q) = %v, want %v", tt.text, tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestIsWordRune(t *testing.T) {
+	for _, r := range "aZé日7_" {
+		if !IsWordRune(r) {
+			t.Errorf("IsWordRune(%q) = false, want true", r)
+		}
+	}
+	for _, r := range " ’'—-.$" {
+		if IsWordRune(r) {
+			t.Errorf("IsWordRune(%q) = true, want false", r)
+		}
+	}
+}